@@ -0,0 +1,46 @@
+// +build all data_sources data_build_definition
+// +build !exclude_data_sources !exclude_data_build_definition
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that the build definition data sources find a build definition by name and path as well as by folder
+// and repository
+func TestAccBuildDefinition_DataSource(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	buildDefinitionName := testutils.GenerateResourceName()
+	buildPath := `\` + testutils.GenerateResourceName()
+
+	tfConfig := fmt.Sprintf("%s\n%s",
+		testutils.HclBuildDefinitionResourceTfsGit(projectName, gitRepoName, buildDefinitionName, buildPath),
+		testutils.HclBuildDefinitionDataSources(buildDefinitionName, buildPath))
+
+	tfNode := "data.azuredevops_build_definition.build"
+	tfListNode := "data.azuredevops_build_definitions.builds"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: tfConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(tfNode, "id", "azuredevops_build_definition.build", "id"),
+					resource.TestCheckResourceAttr(tfNode, "name", buildDefinitionName),
+					resource.TestCheckResourceAttr(tfNode, "path", buildPath),
+					resource.TestCheckResourceAttr(tfNode, "repository.0.yml_path", "path/to/yaml"),
+					resource.TestCheckResourceAttrSet(tfNode, "revision"),
+					resource.TestCheckResourceAttr(tfListNode, "definitions.#", "1"),
+					resource.TestCheckResourceAttr(tfListNode, "definitions.0.name", buildDefinitionName),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,43 @@
+// +build all data_sources git data_git_repository_branches
+// +build !exclude_data_sources !exclude_git !data_git_repository_branches
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that the following sequence of events occurrs without error:
+//	(1) TF can create a repository with an additional branch
+//	(2) A data source is added to the configuration, and that data source finds only the filtered branch
+func TestAccGitRepositoryBranches_DataSource(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	branchName := "feature-" + testutils.GenerateResourceName()
+	tfConfigStep1 := testutils.HclGitRepositoryBranchResource(projectName, gitRepoName, branchName)
+	tfConfigStep2 := fmt.Sprintf("%s\n%s", tfConfigStep1, testutils.HclGitRepositoryBranchesDataSource("refs/heads/", "feature-"))
+
+	tfNode := "data.azuredevops_git_repository_branches.branches"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                  func() { testutils.PreCheck(t, nil) },
+		Providers:                 testutils.GetProviders(),
+		PreventPostDestroyRefresh: true,
+		Steps: []resource.TestStep{
+			{
+				Config: tfConfigStep1,
+			}, {
+				Config: tfConfigStep2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "branches.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "branches.0.name", "refs/heads/"+branchName),
+					resource.TestCheckResourceAttrSet(tfNode, "branches.0.object_id"),
+					resource.TestCheckResourceAttr(tfNode, "branches.0.is_locked", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,42 @@
+// +build all data_sources git data_git_repository_file
+// +build !exclude_data_sources !exclude_git !data_git_repository_file
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that the following sequence of events occurrs without error:
+//	(1) TF can commit a file to a repository
+//	(2) A data source is added to the configuration, and that data source reads the content of the file
+func TestAccGitRepositoryFile_DataSource(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	tfConfigStep1 := testutils.HclGitRepositoryFileResource(projectName, gitRepoName, "VERSION", "1.0.0")
+	tfConfigStep2 := fmt.Sprintf("%s\n%s", tfConfigStep1, testutils.HclGitRepositoryFileDataSource("VERSION"))
+
+	tfNode := "data.azuredevops_git_repository_file.file"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                  func() { testutils.PreCheck(t, nil) },
+		Providers:                 testutils.GetProviders(),
+		PreventPostDestroyRefresh: true,
+		Steps: []resource.TestStep{
+			{
+				Config: tfConfigStep1,
+			}, {
+				Config: tfConfigStep2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "content", "1.0.0"),
+					resource.TestCheckResourceAttr(tfNode, "is_binary", "false"),
+					resource.TestCheckResourceAttrPair(tfNode, "object_id", "azuredevops_git_repository_file.file", "object_id"),
+					resource.TestCheckResourceAttrSet(tfNode, "last_commit_id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,48 @@
+// +build all data_sources data_release_definition
+// +build !exclude_data_sources !exclude_data_release_definition
+
+package acceptancetests
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that the release definition data sources find a release definition by name and expose its stages
+func TestAccReleaseDefinition_DataSource(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	releaseDefinitionName := testutils.GenerateResourceName()
+	principalName := os.Getenv("AZDO_TEST_AAD_USER_EMAIL")
+
+	tfConfig := fmt.Sprintf("%s\n%s",
+		testutils.HclReleaseDefinitionResource(projectName, releaseDefinitionName, principalName, "Prod"),
+		testutils.HclReleaseDefinitionDataSources(releaseDefinitionName))
+
+	tfNode := "data.azuredevops_release_definition.release"
+	tfListNode := "data.azuredevops_release_definitions.releases"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, &[]string{"AZDO_TEST_AAD_USER_EMAIL"}) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: tfConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(tfNode, "id", "azuredevops_release_definition.release", "id"),
+					resource.TestCheckResourceAttr(tfNode, "name", releaseDefinitionName),
+					resource.TestCheckResourceAttr(tfNode, "path", `\`),
+					resource.TestCheckResourceAttr(tfNode, "stage.#", "2"),
+					resource.TestCheckResourceAttrPair(tfNode, "stage.1.id", "azuredevops_release_definition.release", "stage.1.id"),
+					resource.TestCheckResourceAttrSet(tfNode, "revision"),
+					resource.TestCheckResourceAttr(tfListNode, "definitions.#", "1"),
+					resource.TestCheckResourceAttr(tfListNode, "definitions.0.name", releaseDefinitionName),
+					resource.TestCheckResourceAttr(tfListNode, "definitions.0.stages.#", "2"),
+					resource.TestCheckResourceAttr(tfListNode, "definitions.0.stages.1.name", "Prod"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,40 @@
+// +build all data_sources data_task_group
+// +build !exclude_data_sources !exclude_data_task_group
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that the task group data source finds a task group by name
+func TestAccTaskGroup_DataSource(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	taskGroupName := testutils.GenerateResourceName()
+
+	tfConfig := fmt.Sprintf("%s\n%s",
+		testutils.HclTaskGroupResource(projectName, taskGroupName, 1, false),
+		testutils.HclTaskGroupDataSource(taskGroupName))
+
+	tfNode := "data.azuredevops_task_group.task_group"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: tfConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(tfNode, "id", "azuredevops_task_group.task_group", "id"),
+					resource.TestCheckResourceAttr(tfNode, "name", taskGroupName),
+					resource.TestCheckResourceAttr(tfNode, "version", "1.0.0"),
+					resource.TestCheckResourceAttr(tfNode, "input.0.name", "Message"),
+					resource.TestCheckResourceAttr(tfNode, "task.0.display_name", "Print message"),
+				),
+			},
+		},
+	})
+}
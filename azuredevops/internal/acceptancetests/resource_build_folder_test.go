@@ -0,0 +1,48 @@
+// +build all resource_build_folder
+// +build !exclude_resource_build_folder
+
+package acceptancetests
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a build folder can be created, moved and imported
+func TestAccBuildFolder_CreateUpdateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	path := `\` + testutils.GenerateResourceName()
+	movedPath := `\` + testutils.GenerateResourceName()
+	tfNode := "azuredevops_build_folder.folder"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclBuildFolderResource(projectName, path, "Managed by Terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "project_id"),
+					resource.TestCheckResourceAttr(tfNode, "id", path),
+					resource.TestCheckResourceAttr(tfNode, "path", path),
+					resource.TestCheckResourceAttr(tfNode, "description", "Managed by Terraform"),
+				),
+			}, {
+				Config: testutils.HclBuildFolderResource(projectName, movedPath, "Moved by Terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "id", movedPath),
+					resource.TestCheckResourceAttr(tfNode, "path", movedPath),
+					resource.TestCheckResourceAttr(tfNode, "description", "Moved by Terraform"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,54 @@
+// +build all resource_build_run
+// +build !exclude_resource_build_run
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a build is queued and awaited, and that a new build is only queued when the triggers change
+func TestAccBuildRun_CreateAndRerunOnTriggerChange(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	buildDefinitionName := testutils.GenerateResourceName()
+	tfNode := "azuredevops_build_run.run"
+
+	var firstBuildID string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclBuildRunResource(projectName, gitRepoName, buildDefinitionName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "build_number"),
+					resource.TestCheckResourceAttrSet(tfNode, "source_version"),
+					resource.TestCheckResourceAttr(tfNode, "status", "completed"),
+					resource.TestCheckResourceAttr(tfNode, "result", "succeeded"),
+					func(s *terraform.State) error {
+						firstBuildID = s.RootModule().Resources[tfNode].Primary.ID
+						return nil
+					},
+				),
+			}, {
+				Config: testutils.HclBuildRunResource(projectName, gitRepoName, buildDefinitionName, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "result", "succeeded"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[tfNode].Primary.ID == firstBuildID {
+							return fmt.Errorf("Changing the triggers did not queue a new build")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,46 @@
+// +build all resource_check_approval
+// +build !exclude_resource_check_approval
+
+package acceptancetests
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that an approval check can be attached to an environment, updated in place and imported
+func TestAccCheckApproval_CreateUpdateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	environmentName := testutils.GenerateResourceName()
+	tfNode := "azuredevops_check_approval.check"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclCheckApprovalResource(projectName, environmentName, "Approve the deployment"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttrPair(tfNode, "target_resource_id", "azuredevops_environment.environment", "id"),
+					resource.TestCheckResourceAttr(tfNode, "target_resource_type", "environment"),
+					resource.TestCheckResourceAttr(tfNode, "instructions", "Approve the deployment"),
+					resource.TestCheckResourceAttrPair(tfNode, "approvers.0", "data.azuredevops_group.group", "descriptor"),
+				),
+			}, {
+				Config: testutils.HclCheckApprovalResource(projectName, environmentName, "Verify the release notes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "instructions", "Verify the release notes"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,45 @@
+// +build all resource_check_branch_control
+// +build !exclude_resource_check_branch_control
+
+package acceptancetests
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a branch control check can be attached to an environment, updated in place and imported
+func TestAccCheckBranchControl_CreateUpdateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	environmentName := testutils.GenerateResourceName()
+	tfNode := "azuredevops_check_branch_control.check"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclCheckBranchControlResource(projectName, environmentName, "refs/heads/master"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttrPair(tfNode, "target_resource_id", "azuredevops_environment.environment", "id"),
+					resource.TestCheckResourceAttr(tfNode, "target_resource_type", "environment"),
+					resource.TestCheckResourceAttr(tfNode, "allowed_branches", "refs/heads/master"),
+				),
+			}, {
+				Config: testutils.HclCheckBranchControlResource(projectName, environmentName, "refs/heads/master,refs/heads/release/*"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "allowed_branches", "refs/heads/master,refs/heads/release/*"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,45 @@
+// +build all resource_check_business_hours
+// +build !exclude_resource_check_business_hours
+
+package acceptancetests
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a business hours check can be attached to an environment, updated in place and imported
+func TestAccCheckBusinessHours_CreateUpdateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	environmentName := testutils.GenerateResourceName()
+	tfNode := "azuredevops_check_business_hours.check"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclCheckBusinessHoursResource(projectName, environmentName, "08:00"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttrPair(tfNode, "target_resource_id", "azuredevops_environment.environment", "id"),
+					resource.TestCheckResourceAttr(tfNode, "target_resource_type", "environment"),
+					resource.TestCheckResourceAttr(tfNode, "start_time", "08:00"),
+				),
+			}, {
+				Config: testutils.HclCheckBusinessHoursResource(projectName, environmentName, "09:30"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "start_time", "09:30"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
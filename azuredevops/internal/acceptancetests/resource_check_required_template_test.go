@@ -0,0 +1,45 @@
+// +build all resource_check_required_template
+// +build !exclude_resource_check_required_template
+
+package acceptancetests
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a required template check can be attached to an environment, updated in place and imported
+func TestAccCheckRequiredTemplate_CreateUpdateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	environmentName := testutils.GenerateResourceName()
+	tfNode := "azuredevops_check_required_template.check"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclCheckRequiredTemplateResource(projectName, environmentName, "templates/deploy.yml"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttrPair(tfNode, "target_resource_id", "azuredevops_environment.environment", "id"),
+					resource.TestCheckResourceAttr(tfNode, "target_resource_type", "environment"),
+					resource.TestCheckResourceAttr(tfNode, "required_template.0.template_path", "templates/deploy.yml"),
+				),
+			}, {
+				Config: testutils.HclCheckRequiredTemplateResource(projectName, environmentName, "templates/release.yml"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "required_template.0.template_path", "templates/release.yml"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
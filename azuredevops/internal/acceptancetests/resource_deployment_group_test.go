@@ -0,0 +1,52 @@
+// +build all resource_deployment_group
+// +build !exclude_resource_deployment_group
+
+package acceptancetests
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a deployment group can be linked to a deployment pool, updated in place, listed and imported
+func TestAccDeploymentGroup_CreateUpdateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	poolName := testutils.GenerateResourceName()
+	deploymentGroupName := testutils.GenerateResourceName()
+	deploymentGroupNameUpdated := testutils.GenerateResourceName()
+	tfNode := "azuredevops_deployment_group.deployment_group"
+	tfTargetsNode := "data.azuredevops_deployment_targets.targets"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclDeploymentGroupResource(projectName, poolName, deploymentGroupName, "Managed by Terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "project_id"),
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttrPair(tfNode, "pool_id", "azuredevops_agent_pool.pool", "id"),
+					resource.TestCheckResourceAttr(tfNode, "name", deploymentGroupName),
+					resource.TestCheckResourceAttr(tfNode, "description", "Managed by Terraform"),
+					resource.TestCheckResourceAttr(tfNode, "machine_count", "0"),
+					resource.TestCheckResourceAttr(tfTargetsNode, "targets.#", "0"),
+				),
+			}, {
+				Config: testutils.HclDeploymentGroupResource(projectName, poolName, deploymentGroupNameUpdated, "Updated by Terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "name", deploymentGroupNameUpdated),
+					resource.TestCheckResourceAttr(tfNode, "description", "Updated by Terraform"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,54 @@
+// +build all resource_environment_resource_kubernetes
+// +build !exclude_resource_environment_resource_kubernetes
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a namespace of a Kubernetes cluster can be linked to an environment and the link can be imported
+func TestAccEnvironmentResourceKubernetes_CreateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	environmentName := testutils.GenerateResourceName()
+	serviceEndpointName := testutils.GenerateResourceName()
+	tfNode := "azuredevops_environment_resource_kubernetes.kubernetes"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclEnvironmentResourceKubernetes(projectName, environmentName, serviceEndpointName, "frontend"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttrPair(tfNode, "environment_id", "azuredevops_environment.environment", "id"),
+					resource.TestCheckResourceAttrPair(tfNode, "service_endpoint_id", "azuredevops_serviceendpoint_kubernetes.serviceendpoint", "id"),
+					resource.TestCheckResourceAttr(tfNode, "name", "frontend"),
+					resource.TestCheckResourceAttr(tfNode, "namespace", "frontend"),
+					resource.TestCheckResourceAttr(tfNode, "cluster_name", "sample-aks"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: computeEnvironmentResourceKubernetesImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func computeEnvironmentResourceKubernetesImportID(resourceNode string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		res := s.RootModule().Resources[resourceNode]
+		projectID := res.Primary.Attributes["project_id"]
+		environmentID := res.Primary.Attributes["environment_id"]
+		return fmt.Sprintf("%s/%s/%s", projectID, environmentID, res.Primary.ID), nil
+	}
+}
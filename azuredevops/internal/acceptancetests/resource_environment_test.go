@@ -0,0 +1,47 @@
+// +build all resource_environment
+// +build !exclude_resource_environment
+
+package acceptancetests
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that an environment can be created, updated in place and imported
+func TestAccEnvironment_CreateUpdateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	environmentName := testutils.GenerateResourceName()
+	environmentNameUpdated := testutils.GenerateResourceName()
+	tfNode := "azuredevops_environment.environment"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclEnvironmentResource(projectName, environmentName, "Managed by Terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "project_id"),
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "name", environmentName),
+					resource.TestCheckResourceAttr(tfNode, "description", "Managed by Terraform"),
+				),
+			}, {
+				Config: testutils.HclEnvironmentResource(projectName, environmentNameUpdated, "Updated by Terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "name", environmentNameUpdated),
+					resource.TestCheckResourceAttr(tfNode, "description", "Updated by Terraform"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
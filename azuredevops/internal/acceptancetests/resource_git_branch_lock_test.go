@@ -0,0 +1,51 @@
+// +build all git resource_git_branch_lock
+// +build !exclude_git !exclude_resource_git_branch_lock
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that the default branch of a repository can be locked and the lock can be imported
+func TestAccGitBranchLock_CreateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	tfNode := "azuredevops_git_branch_lock.lock"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclGitBranchLockResource(projectName, gitRepoName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "project_id"),
+					resource.TestCheckResourceAttrSet(tfNode, "repository_id"),
+					resource.TestCheckResourceAttr(tfNode, "ref", "refs/heads/master"),
+					resource.TestCheckResourceAttrSet(tfNode, "is_locked_by"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: computeGitBranchLockImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func computeGitBranchLockImportID(resourceNode string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		res := s.RootModule().Resources[resourceNode]
+		projectID := res.Primary.Attributes["project_id"]
+		repositoryID := res.Primary.Attributes["repository_id"]
+		ref := res.Primary.Attributes["ref"]
+		return fmt.Sprintf("%s/%s/%s", projectID, repositoryID, ref), nil
+	}
+}
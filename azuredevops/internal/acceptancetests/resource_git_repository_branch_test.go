@@ -0,0 +1,52 @@
+// +build all git resource_git_repository_branch
+// +build !exclude_git !exclude_resource_git_repository_branch
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a branch can be created from the default branch of a repository and can be imported
+func TestAccGitRepositoryBranch_CreateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	branchName := "release/" + testutils.GenerateResourceName()
+	tfNode := "azuredevops_git_repository_branch.branch"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclGitRepositoryBranchResource(projectName, gitRepoName, branchName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "name", branchName),
+					resource.TestCheckResourceAttr(tfNode, "ref", "refs/heads/"+branchName),
+					resource.TestCheckResourceAttrSet(tfNode, "last_commit_id"),
+				),
+			}, {
+				ResourceName:            tfNode,
+				ImportStateIdFunc:       computeGitRepositoryBranchImportID(tfNode),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ref_branch"},
+			},
+		},
+	})
+}
+
+func computeGitRepositoryBranchImportID(resourceNode string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		res := s.RootModule().Resources[resourceNode]
+		projectID := res.Primary.Attributes["project_id"]
+		repositoryID := res.Primary.Attributes["repository_id"]
+		name := res.Primary.Attributes["name"]
+		return fmt.Sprintf("%s/%s/%s", projectID, repositoryID, name), nil
+	}
+}
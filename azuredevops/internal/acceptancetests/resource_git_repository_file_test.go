@@ -0,0 +1,57 @@
+// +build all git resource_git_repository_file
+// +build !exclude_git !exclude_resource_git_repository_file
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a file can be committed to a repository, updated and imported
+func TestAccGitRepositoryFile_CreateUpdateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	tfNode := "azuredevops_git_repository_file.file"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclGitRepositoryFileResource(projectName, gitRepoName, "CODEOWNERS", "* @first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "file", "CODEOWNERS"),
+					resource.TestCheckResourceAttr(tfNode, "content", "* @first"),
+					resource.TestCheckResourceAttrSet(tfNode, "object_id"),
+				),
+			}, {
+				Config: testutils.HclGitRepositoryFileResource(projectName, gitRepoName, "CODEOWNERS", "* @second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "content", "* @second"),
+				),
+			}, {
+				ResourceName:            tfNode,
+				ImportStateIdFunc:       computeGitRepositoryFileImportID(tfNode),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"commit_message"},
+			},
+		},
+	})
+}
+
+func computeGitRepositoryFileImportID(resourceNode string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		res := s.RootModule().Resources[resourceNode]
+		projectID := res.Primary.Attributes["project_id"]
+		repositoryID := res.Primary.Attributes["repository_id"]
+		file := res.Primary.Attributes["file"]
+		branch := res.Primary.Attributes["branch"]
+		return fmt.Sprintf("%s/%s/%s:%s", projectID, repositoryID, file, branch), nil
+	}
+}
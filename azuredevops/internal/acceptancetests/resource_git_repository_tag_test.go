@@ -0,0 +1,57 @@
+// +build all git resource_git_repository_tag
+// +build !exclude_git !exclude_resource_git_repository_tag
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that annotated and lightweight tags can be created and imported
+func TestAccGitRepositoryTag_CreateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	tagName := "v1.0"
+	tfAnnotatedNode := "azuredevops_git_repository_tag.annotated"
+	tfLightweightNode := "azuredevops_git_repository_tag.lightweight"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclGitRepositoryTagResource(projectName, gitRepoName, tagName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfAnnotatedNode, "ref", "refs/tags/"+tagName),
+					resource.TestCheckResourceAttr(tfAnnotatedNode, "message", "Release "+tagName),
+					resource.TestCheckResourceAttrSet(tfAnnotatedNode, "object_id"),
+					resource.TestCheckResourceAttrSet(tfAnnotatedNode, "commit_id"),
+					resource.TestCheckResourceAttr(tfLightweightNode, "ref", "refs/tags/"+tagName+"-lightweight"),
+					resource.TestCheckResourceAttrPair(tfLightweightNode, "commit_id", tfAnnotatedNode, "commit_id"),
+					resource.TestCheckResourceAttrPair(tfLightweightNode, "object_id", tfLightweightNode, "commit_id"),
+				),
+			}, {
+				ResourceName:            tfAnnotatedNode,
+				ImportStateIdFunc:       computeGitRepositoryTagImportID(tfAnnotatedNode),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ref_branch"},
+			},
+		},
+	})
+}
+
+func computeGitRepositoryTagImportID(resourceNode string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		res := s.RootModule().Resources[resourceNode]
+		projectID := res.Primary.Attributes["project_id"]
+		repositoryID := res.Primary.Attributes["repository_id"]
+		name := res.Primary.Attributes["name"]
+		return fmt.Sprintf("%s/%s/%s", projectID, repositoryID, name), nil
+	}
+}
@@ -117,6 +117,72 @@ func TestAccGitRepo_Create_Import(t *testing.T) {
 
 }
 
+// Verifies that a repository can be disabled, enabled and renamed in place and
+// that forking can be turned off
+func TestAccGitRepo_Settings_DisableAndRename(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoNameFirst := testutils.GenerateResourceName()
+	gitRepoNameSecond := testutils.GenerateResourceName()
+	tfRepoNode := "azuredevops_git_repository.repository"
+
+	hclRepoWithSettings := func(repoName string, isDisabled bool, allowForks bool) string {
+		return fmt.Sprintf(`
+%s
+
+resource "azuredevops_git_repository" "repository" {
+	project_id  = azuredevops_project.project.id
+	name        = "%s"
+	is_disabled = %t
+	allow_forks = %t
+	initialization {
+		init_type = "Clean"
+	}
+}`, testutils.HclProjectResource(projectName), repoName, isDisabled, allowForks)
+	}
+
+	var repoID string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: checkGitRepoDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: hclRepoWithSettings(gitRepoNameFirst, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfRepoNode, "is_disabled", "false"),
+					resource.TestCheckResourceAttr(tfRepoNode, "allow_forks", "false"),
+					func(s *terraform.State) error {
+						repoID = s.RootModule().Resources[tfRepoNode].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: hclRepoWithSettings(gitRepoNameFirst, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfRepoNode, "is_disabled", "true"),
+					resource.TestCheckResourceAttr(tfRepoNode, "name", gitRepoNameFirst),
+				),
+			},
+			{
+				Config: hclRepoWithSettings(gitRepoNameSecond, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfRepoNode, "is_disabled", "false"),
+					resource.TestCheckResourceAttr(tfRepoNode, "allow_forks", "true"),
+					resource.TestCheckResourceAttr(tfRepoNode, "name", gitRepoNameSecond),
+					checkGitRepoExists(gitRepoNameSecond),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[tfRepoNode].Primary.ID; id != repoID {
+							return fmt.Errorf("Repository has been replaced instead of renamed: %s != %s", id, repoID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 // Verifies that a newly created repo with init_type of "Clean" has the expected
 // master branch available
 func TestAccGitRepo_RepoInitialization_Clean(t *testing.T) {
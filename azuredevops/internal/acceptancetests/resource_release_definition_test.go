@@ -0,0 +1,56 @@
+// +build all resource_release_definition
+// +build !exclude_resource_release_definition
+
+package acceptancetests
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a release definition can be created, its stages be updated in place and imported
+func TestAccReleaseDefinition_CreateUpdateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	releaseDefinitionName := testutils.GenerateResourceName()
+	principalName := os.Getenv("AZDO_TEST_AAD_USER_EMAIL")
+	tfNode := "azuredevops_release_definition.release"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, &[]string{"AZDO_TEST_AAD_USER_EMAIL"}) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclReleaseDefinitionResource(projectName, releaseDefinitionName, principalName, "Test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "project_id"),
+					resource.TestCheckResourceAttrSet(tfNode, "revision"),
+					resource.TestCheckResourceAttr(tfNode, "name", releaseDefinitionName),
+					resource.TestCheckResourceAttr(tfNode, "stage.#", "2"),
+					resource.TestCheckResourceAttr(tfNode, "stage.0.name", "Dev"),
+					resource.TestCheckResourceAttrSet(tfNode, "stage.0.id"),
+					resource.TestCheckResourceAttr(tfNode, "stage.0.pre_deploy_approval.#", "0"),
+					resource.TestCheckResourceAttr(tfNode, "stage.1.name", "Test"),
+					resource.TestCheckResourceAttr(tfNode, "stage.1.pre_deploy_approval.#", "1"),
+				),
+			}, {
+				Config: testutils.HclReleaseDefinitionResource(projectName, releaseDefinitionName, principalName, "Prod"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "stage.#", "2"),
+					resource.TestCheckResourceAttr(tfNode, "stage.0.name", "Dev"),
+					resource.TestCheckResourceAttr(tfNode, "stage.1.name", "Prod"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+				// all inputs of a task are imported, including the ones using the default value
+				ImportStateVerifyIgnore: []string{"stage.0.deploy_phase.0.task.0.inputs"},
+			},
+		},
+	})
+}
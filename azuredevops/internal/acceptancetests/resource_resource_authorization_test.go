@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
 )
 
@@ -81,3 +82,45 @@ func TestAccResourceAuthorization_Definition_CRUD(t *testing.T) {
 		},
 	})
 }
+
+func TestAccResourceAuthorization_Repository_CRUDAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	repositoryName := testutils.GenerateResourceName()
+
+	repositoryHCL := testutils.HclGitRepoResource(projectName, repositoryName, "Clean")
+	authedHCL := testutils.HclTypedResourceAuthorization("azuredevops_git_repository.repository.id", "repository", true)
+	unAuthedHCL := testutils.HclTypedResourceAuthorization("azuredevops_git_repository.repository.id", "repository", false)
+
+	tfAuthNode := "azuredevops_resource_authorization.auth"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s\n%s", repositoryHCL, authedHCL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfAuthNode, "project_id"),
+					resource.TestCheckResourceAttrSet(tfAuthNode, "resource_id"),
+					resource.TestCheckResourceAttr(tfAuthNode, "type", "repository"),
+					resource.TestCheckResourceAttr(tfAuthNode, "authorized", "true"),
+				),
+			}, {
+				ResourceName: tfAuthNode,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					res, ok := s.RootModule().Resources[tfAuthNode]
+					if !ok {
+						return "", fmt.Errorf("Did not find a resource authorization in the TF state")
+					}
+					return fmt.Sprintf("%s/repository/%s", res.Primary.Attributes["project_id"], res.Primary.ID), nil
+				},
+				ImportState:       true,
+				ImportStateVerify: true,
+			}, {
+				Config: fmt.Sprintf("%s\n%s", repositoryHCL, unAuthedHCL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfAuthNode, "authorized", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,51 @@
+// +build all resource_secure_file
+// +build !exclude_resource_secure_file
+
+package acceptancetests
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a secure file can be uploaded, renamed in place and replaced with a new content
+func TestAccSecureFile_CreateUpdateAndReplace(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	secureFileName := testutils.GenerateResourceName()
+	secureFileNameUpdated := testutils.GenerateResourceName()
+	tfNode := "azuredevops_secure_file.secure_file"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclSecureFileResource(projectName, secureFileName, "c2VjcmV0", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "project_id"),
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttrSet(tfNode, "content_hash"),
+					resource.TestCheckResourceAttr(tfNode, "name", secureFileName),
+					resource.TestCheckResourceAttr(tfNode, "content_base64", ""),
+					resource.TestCheckResourceAttr(tfNode, "allow_access", "true"),
+					resource.TestCheckResourceAttr(tfNode, "properties.owner", "terraform"),
+				),
+			}, {
+				Config: testutils.HclSecureFileResource(projectName, secureFileNameUpdated, "c2VjcmV0", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "name", secureFileNameUpdated),
+					resource.TestCheckResourceAttr(tfNode, "allow_access", "false"),
+				),
+			}, {
+				Config: testutils.HclSecureFileResource(projectName, secureFileNameUpdated, "dXBkYXRlZCBzZWNyZXQ=", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "name", secureFileNameUpdated),
+				),
+			},
+		},
+	})
+}
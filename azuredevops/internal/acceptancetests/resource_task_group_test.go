@@ -0,0 +1,56 @@
+// +build all resource_task_group
+// +build !exclude_resource_task_group
+
+package acceptancetests
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// Verifies that a task group can be created, published as preview of a new major version, released and imported
+func TestAccTaskGroup_CreatePublishAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	taskGroupName := testutils.GenerateResourceName()
+	tfNode := "azuredevops_task_group.task_group"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclTaskGroupResource(projectName, taskGroupName, 1, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "project_id"),
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "name", taskGroupName),
+					resource.TestCheckResourceAttr(tfNode, "major_version", "1"),
+					resource.TestCheckResourceAttr(tfNode, "preview", "false"),
+					resource.TestCheckResourceAttr(tfNode, "input.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "task.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "task.0.inputs.script", "echo $(Message)"),
+				),
+			}, {
+				Config: testutils.HclTaskGroupResource(projectName, taskGroupName, 2, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "major_version", "2"),
+					resource.TestCheckResourceAttr(tfNode, "preview", "true"),
+				),
+			}, {
+				Config: testutils.HclTaskGroupResource(projectName, taskGroupName, 2, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "major_version", "2"),
+					resource.TestCheckResourceAttr(tfNode, "preview", "false"),
+				),
+			}, {
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
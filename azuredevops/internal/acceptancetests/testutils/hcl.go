@@ -565,6 +565,305 @@ resource "azuredevops_agent_queue" "q" {
 	return fmt.Sprintf("%s\n%s", poolHCL, queueHCL)
 }
 
+// HclEnvironmentResource HCL describing an AzDO pipeline environment
+func HclEnvironmentResource(projectName string, environmentName string, description string) string {
+	environmentResource := fmt.Sprintf(`
+resource "azuredevops_environment" "environment" {
+	project_id  = azuredevops_project.project.id
+	name        = "%s"
+	description = "%s"
+}`, environmentName, description)
+	return fmt.Sprintf("%s\n%s", HclProjectResource(projectName), environmentResource)
+}
+
+// HclEnvironmentResourceKubernetes HCL describing a namespace of a Kubernetes cluster linked to an AzDO pipeline environment
+func HclEnvironmentResourceKubernetes(projectName string, environmentName string, serviceEndpointName string, namespace string) string {
+	kubernetesResource := fmt.Sprintf(`
+resource "azuredevops_environment" "environment" {
+	project_id = azuredevops_project.project.id
+	name       = "%s"
+}
+
+resource "azuredevops_environment_resource_kubernetes" "kubernetes" {
+	project_id          = azuredevops_project.project.id
+	environment_id      = azuredevops_environment.environment.id
+	service_endpoint_id = azuredevops_serviceendpoint_kubernetes.serviceendpoint.id
+	name                = "%s"
+	namespace           = "%s"
+	cluster_name        = "sample-aks"
+}`, environmentName, namespace, namespace)
+	return fmt.Sprintf("%s\n%s", HclServiceEndpointKubernetesResource(projectName, serviceEndpointName, "ServiceAccount"), kubernetesResource)
+}
+
+// HclDeploymentGroupResource HCL describing an AzDO deployment group registered in a new deployment pool
+func HclDeploymentGroupResource(projectName string, poolName string, deploymentGroupName string, description string) string {
+	deploymentGroupResource := fmt.Sprintf(`
+resource "azuredevops_agent_pool" "pool" {
+	name      = "%s"
+	pool_type = "deployment"
+}
+
+resource "azuredevops_deployment_group" "deployment_group" {
+	project_id  = azuredevops_project.project.id
+	name        = "%s"
+	description = "%s"
+	pool_id     = azuredevops_agent_pool.pool.id
+}
+
+data "azuredevops_deployment_targets" "targets" {
+	project_id          = azuredevops_project.project.id
+	deployment_group_id = azuredevops_deployment_group.deployment_group.id
+}`, poolName, deploymentGroupName, description)
+	return fmt.Sprintf("%s\n%s", HclProjectResource(projectName), deploymentGroupResource)
+}
+
+// HclSecureFileResource HCL describing an AzDO secure file uploaded from base64 content
+func HclSecureFileResource(projectName string, secureFileName string, contentBase64 string, allowAccess bool) string {
+	secureFileResource := fmt.Sprintf(`
+resource "azuredevops_secure_file" "secure_file" {
+	project_id     = azuredevops_project.project.id
+	name           = "%s"
+	content_base64 = "%s"
+	allow_access   = %t
+	properties = {
+		owner = "terraform"
+	}
+}`, secureFileName, contentBase64, allowAccess)
+	return fmt.Sprintf("%s\n%s", HclProjectResource(projectName), secureFileResource)
+}
+
+// HclCheckApprovalResource HCL describing an approval check of an AzDO pipeline environment, which is approved by
+// the contributors of the project
+func HclCheckApprovalResource(projectName string, environmentName string, instructions string) string {
+	checkResource := fmt.Sprintf(`
+data "azuredevops_group" "group" {
+	project_id = azuredevops_project.project.id
+	name       = "Contributors"
+}
+
+resource "azuredevops_check_approval" "check" {
+	project_id           = azuredevops_project.project.id
+	target_resource_id   = azuredevops_environment.environment.id
+	target_resource_type = "environment"
+	approvers            = [data.azuredevops_group.group.descriptor]
+	instructions         = "%s"
+	timeout              = 600
+}`, instructions)
+	return fmt.Sprintf("%s\n%s", HclEnvironmentResource(projectName, environmentName, ""), checkResource)
+}
+
+// HclCheckBranchControlResource HCL describing a branch control check of an AzDO pipeline environment
+func HclCheckBranchControlResource(projectName string, environmentName string, allowedBranches string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_branch_control" "check" {
+	project_id           = azuredevops_project.project.id
+	target_resource_id   = azuredevops_environment.environment.id
+	target_resource_type = "environment"
+	allowed_branches     = "%s"
+}`, allowedBranches)
+	return fmt.Sprintf("%s\n%s", HclEnvironmentResource(projectName, environmentName, ""), checkResource)
+}
+
+// HclCheckBusinessHoursResource HCL describing a business hours check of an AzDO pipeline environment
+func HclCheckBusinessHoursResource(projectName string, environmentName string, startTime string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_business_hours" "check" {
+	project_id           = azuredevops_project.project.id
+	target_resource_id   = azuredevops_environment.environment.id
+	target_resource_type = "environment"
+	business_days        = ["monday", "tuesday", "wednesday", "thursday", "friday"]
+	time_zone            = "UTC"
+	start_time           = "%s"
+	end_time             = "17:00"
+}`, startTime)
+	return fmt.Sprintf("%s\n%s", HclEnvironmentResource(projectName, environmentName, ""), checkResource)
+}
+
+// HclCheckRequiredTemplateResource HCL describing a required template check of an AzDO pipeline environment
+func HclCheckRequiredTemplateResource(projectName string, environmentName string, templatePath string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_required_template" "check" {
+	project_id           = azuredevops_project.project.id
+	target_resource_id   = azuredevops_environment.environment.id
+	target_resource_type = "environment"
+
+	required_template {
+		repository_name = "${azuredevops_project.project.name}/${azuredevops_project.project.name}"
+		repository_ref  = "refs/heads/master"
+		template_path   = "%s"
+	}
+}`, templatePath)
+	return fmt.Sprintf("%s\n%s", HclEnvironmentResource(projectName, environmentName, ""), checkResource)
+}
+
+// HclBuildFolderResource HCL describing an AzDO build folder
+func HclBuildFolderResource(projectName string, path string, description string) string {
+	escapedPath := strings.ReplaceAll(path, `\`, `\\`)
+	folderHCL := fmt.Sprintf(`
+resource "azuredevops_build_folder" "folder" {
+	project_id  = azuredevops_project.project.id
+	path        = "%s"
+	description = "%s"
+}`, escapedPath, description)
+
+	return fmt.Sprintf("%s\n%s", HclProjectResource(projectName), folderHCL)
+}
+
+// HclBuildRunResource HCL describing a build run of a YAML build definition which is committed to an AzDO git repository
+func HclBuildRunResource(projectName string, gitRepoName string, buildDefinitionName string, trigger string) string {
+	pipelineFileResource := HclGitRepositoryFileResource(projectName, gitRepoName, "azure-pipelines.yml",
+		`trigger: none\nsteps:\n- script: echo $(greeting)\n`)
+	buildRunResources := fmt.Sprintf(`
+resource "azuredevops_build_definition" "build" {
+	project_id = azuredevops_project.project.id
+	name       = "%s"
+
+	repository {
+		repo_type   = "TfsGit"
+		repo_id     = azuredevops_git_repository.repository.id
+		branch_name = azuredevops_git_repository_file.file.branch
+		yml_path    = azuredevops_git_repository_file.file.file
+	}
+}
+
+resource "azuredevops_build_run" "run" {
+	project_id    = azuredevops_project.project.id
+	definition_id = azuredevops_build_definition.build.id
+	branch        = azuredevops_git_repository_file.file.branch
+
+	variables = {
+		greeting = "Hello from Terraform"
+	}
+
+	triggers = {
+		trigger = "%s"
+	}
+}`, buildDefinitionName, trigger)
+	return fmt.Sprintf("%s\n%s", pipelineFileResource, buildRunResources)
+}
+
+// HclReleaseDefinitionResource HCL describing an AzDO release definition with two stages, of which the second one requires an approval
+func HclReleaseDefinitionResource(projectName string, releaseDefinitionName string, principalName string, secondStageName string) string {
+	releaseDefinitionResources := fmt.Sprintf(`
+data "azuredevops_agent_queue" "queue" {
+	project_id = azuredevops_project.project.id
+	name       = "Azure Pipelines"
+}
+
+resource "azuredevops_release_definition" "release" {
+	project_id = azuredevops_project.project.id
+	name       = "%[1]s"
+
+	variable {
+		name  = "greeting"
+		value = "Hello from Terraform"
+	}
+
+	stage {
+		name     = "Dev"
+		owner_id = azuredevops_user_entitlement.user.id
+
+		condition {
+			type = "event"
+			name = "ReleaseStarted"
+		}
+
+		deploy_phase {
+			name                = "Agent job"
+			queue_id            = data.azuredevops_agent_queue.queue.id
+			agent_specification = "ubuntu-20.04"
+
+			task {
+				task_id      = "d9bafed4-0b18-4f58-968d-86655b4d2ce9"
+				version      = "2.*"
+				display_name = "Greet"
+				inputs = {
+					script = "echo $(greeting)"
+				}
+			}
+		}
+	}
+
+	stage {
+		name     = "%[2]s"
+		owner_id = azuredevops_user_entitlement.user.id
+
+		condition {
+			type  = "environmentState"
+			name  = "Dev"
+			value = "4"
+		}
+
+		pre_deploy_approval {
+			approver_ids = [azuredevops_user_entitlement.user.id]
+		}
+
+		deploy_phase {
+			name = "Agentless job"
+			type = "runOnServer"
+		}
+	}
+}`, releaseDefinitionName, secondStageName)
+	return fmt.Sprintf("%s\n%s\n%s", HclProjectResource(projectName), HclUserEntitlementResource(principalName), releaseDefinitionResources)
+}
+
+// HclReleaseDefinitionDataSources HCL describing the AzDO release definition data sources looking up the release
+// definition of HclReleaseDefinitionResource by name
+func HclReleaseDefinitionDataSources(releaseDefinitionName string) string {
+	return fmt.Sprintf(`
+data "azuredevops_release_definition" "release" {
+	project_id = azuredevops_project.project.id
+	name       = "%[1]s"
+	depends_on = [azuredevops_release_definition.release]
+}
+
+data "azuredevops_release_definitions" "releases" {
+	project_id = azuredevops_project.project.id
+	name       = "%[1]s"
+	depends_on = [azuredevops_release_definition.release]
+}`, releaseDefinitionName)
+}
+
+// HclTaskGroupResource HCL describing an AzDO task group running a command line script with an input
+func HclTaskGroupResource(projectName string, taskGroupName string, majorVersion int, preview bool) string {
+	taskGroupResource := fmt.Sprintf(`
+resource "azuredevops_task_group" "task_group" {
+	project_id    = azuredevops_project.project.id
+	name          = "%s"
+	description   = "Managed by Terraform"
+	major_version = %d
+	preview       = %t
+
+	input {
+		name          = "Message"
+		label         = "Message"
+		default_value = "Hello"
+		required      = true
+	}
+
+	task {
+		task_id      = "d9bafed4-0b18-4f58-968d-86655b4d2ce9"
+		version      = "2.*"
+		display_name = "Print message"
+		inputs = {
+			script = "echo $(Message)"
+		}
+	}
+}`, taskGroupName, majorVersion, preview)
+	return fmt.Sprintf("%s\n%s", HclProjectResource(projectName), taskGroupResource)
+}
+
+// HclTaskGroupDataSource HCL describing the AzDO task group data source looking up the task group of
+// HclTaskGroupResource by name
+func HclTaskGroupDataSource(taskGroupName string) string {
+	return fmt.Sprintf(`
+data "azuredevops_task_group" "task_group" {
+	project_id = azuredevops_project.project.id
+	name       = "%s"
+	depends_on = [azuredevops_task_group.task_group]
+}`, taskGroupName)
+}
+
 // HclBuildDefinitionResourceGitHub HCL describing an AzDO build definition sourced from GitHub
 func HclBuildDefinitionResourceGitHub(projectName string, buildDefinitionName string, buildPath string) string {
 	return HclBuildDefinitionResourceWithProject(
@@ -622,7 +921,7 @@ func HclBuildDefinitionResource(
 	resource "azuredevops_build_definition" "build" {
 		project_id      = azuredevops_project.project.id
 		name            = "%s"
-		agent_pool_name = "Hosted Ubuntu 1604"
+		agent_pool_name = "Azure Pipelines"
 		path			= "%s"
 
 		repository {
@@ -653,6 +952,26 @@ func HclBuildDefinitionResourceWithProject(
 	return fmt.Sprintf("%s\n%s", projectResource, buildDefinitionResource)
 }
 
+// HclBuildDefinitionDataSources HCL describing the AzDO build definition data sources looking up the build definition
+// of HclBuildDefinitionResourceTfsGit by name and by folder
+func HclBuildDefinitionDataSources(buildDefinitionName string, buildPath string) string {
+	escapedBuildPath := strings.ReplaceAll(buildPath, `\`, `\\`)
+	return fmt.Sprintf(`
+data "azuredevops_build_definition" "build" {
+	project_id = azuredevops_project.project.id
+	name       = "%[1]s"
+	path       = "%[2]s"
+	depends_on = [azuredevops_build_definition.build]
+}
+
+data "azuredevops_build_definitions" "builds" {
+	project_id    = azuredevops_project.project.id
+	path          = "%[2]s"
+	repository_id = azuredevops_git_repository.repository.id
+	depends_on    = [azuredevops_build_definition.build]
+}`, buildDefinitionName, escapedBuildPath)
+}
+
 // HclBuildDefinitionWithVariables A build definition with variables
 func HclBuildDefinitionWithVariables(varValue, secretVarValue, name string) string {
 	buildDefinitionResource := fmt.Sprintf(`
@@ -746,6 +1065,17 @@ resource "azuredevops_resource_authorization" "auth" {
 }`, resourceID, authorized)
 }
 
+// HclTypedResourceAuthorization HCL describing the authorization of a resource of the given type for all pipelines
+func HclTypedResourceAuthorization(resourceID, resourceType string, authorized bool) string {
+	return fmt.Sprintf(`
+resource "azuredevops_resource_authorization" "auth" {
+	project_id  = azuredevops_project.project.id
+	resource_id = %s
+	type        = "%s"
+	authorized  = %t
+}`, resourceID, resourceType, authorized)
+}
+
 // HclDefinitionResourceAuthorization HCL describing a resource authorization
 func HclDefinitionResourceAuthorization(resourceID, definitionID, resourceType string, authorized bool) string {
 	return fmt.Sprintf(`
@@ -832,3 +1162,86 @@ resource "azuredevops_git_permissions" "git-permissions" {
 }
 `, projectResource, gitRepository)
 }
+
+// HclGitBranchLockResource HCL describing a lock of the default branch of an AzDO git repository
+func HclGitBranchLockResource(projectName string, gitRepoName string) string {
+	gitRepoResource := HclGitRepoResource(projectName, gitRepoName, "Clean")
+	branchLockResource := `
+resource "azuredevops_git_branch_lock" "lock" {
+	project_id    = azuredevops_project.project.id
+	repository_id = azuredevops_git_repository.repository.id
+	ref           = azuredevops_git_repository.repository.default_branch
+}`
+	return fmt.Sprintf("%s\n%s", gitRepoResource, branchLockResource)
+}
+
+// HclGitRepositoryBranchResource HCL describing a branch of an AzDO git repository created from the default branch
+func HclGitRepositoryBranchResource(projectName string, gitRepoName string, branchName string) string {
+	gitRepoResource := HclGitRepoResource(projectName, gitRepoName, "Clean")
+	branchResource := fmt.Sprintf(`
+resource "azuredevops_git_repository_branch" "branch" {
+	project_id    = azuredevops_project.project.id
+	repository_id = azuredevops_git_repository.repository.id
+	name          = "%s"
+	ref_branch    = azuredevops_git_repository.repository.default_branch
+}`, branchName)
+	return fmt.Sprintf("%s\n%s", gitRepoResource, branchResource)
+}
+
+// HclGitRepositoryFileResource HCL describing a file committed to the default branch of an AzDO git repository
+func HclGitRepositoryFileResource(projectName string, gitRepoName string, fileName string, content string) string {
+	gitRepoResource := HclGitRepoResource(projectName, gitRepoName, "Clean")
+	fileResource := fmt.Sprintf(`
+resource "azuredevops_git_repository_file" "file" {
+	project_id     = azuredevops_project.project.id
+	repository_id  = azuredevops_git_repository.repository.id
+	file           = "%s"
+	content        = "%s"
+	branch         = "refs/heads/master"
+	commit_message = "Seed %s"
+}`, fileName, content, fileName)
+	return fmt.Sprintf("%s\n%s", gitRepoResource, fileResource)
+}
+
+// HclGitRepositoryTagResource HCL describing an annotated and a lightweight tag of an AzDO git repository
+func HclGitRepositoryTagResource(projectName string, gitRepoName string, tagName string) string {
+	gitRepoResource := HclGitRepoResource(projectName, gitRepoName, "Clean")
+	tagResources := fmt.Sprintf(`
+resource "azuredevops_git_repository_tag" "annotated" {
+	project_id    = azuredevops_project.project.id
+	repository_id = azuredevops_git_repository.repository.id
+	name          = "%[1]s"
+	ref_branch    = azuredevops_git_repository.repository.default_branch
+	message       = "Release %[1]s"
+}
+
+resource "azuredevops_git_repository_tag" "lightweight" {
+	project_id    = azuredevops_project.project.id
+	repository_id = azuredevops_git_repository.repository.id
+	name          = "%[1]s-lightweight"
+	ref_commit_id = azuredevops_git_repository_tag.annotated.commit_id
+}`, tagName)
+	return fmt.Sprintf("%s\n%s", gitRepoResource, tagResources)
+}
+
+// HclGitRepositoryBranchesDataSource HCL describing a data source for the refs of an AzDO git repository
+func HclGitRepositoryBranchesDataSource(prefix string, contains string) string {
+	return fmt.Sprintf(`
+data "azuredevops_git_repository_branches" "branches" {
+	project_id    = azuredevops_project.project.id
+	repository_id = azuredevops_git_repository.repository.id
+	prefix        = "%s"
+	contains      = "%s"
+}`, prefix, contains)
+}
+
+// HclGitRepositoryFileDataSource HCL describing a data source for the content of a file in an AzDO git repository
+func HclGitRepositoryFileDataSource(fileName string) string {
+	return fmt.Sprintf(`
+data "azuredevops_git_repository_file" "file" {
+	project_id    = azuredevops_project.project.id
+	repository_id = azuredevops_git_repository.repository.id
+	file          = "%s"
+	branch        = "refs/heads/master"
+}`, fileName)
+}
@@ -0,0 +1,134 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/validate"
+)
+
+// DataBuildDefinition schema and implementation for build definition data source
+func DataBuildDefinition() *schema.Resource {
+	// The data source exposes all attributes of the resource, the arguments
+	// identifying the build definition are overridden below.
+	dataSchema := tfhelper.ComputedSchema(ResourceBuildDefinition().Schema)
+	dataSchema["project_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.NoZeroValues,
+	}
+	dataSchema["build_definition_id"] = &schema.Schema{
+		Type:          schema.TypeInt,
+		Optional:      true,
+		Computed:      true,
+		ValidateFunc:  validation.IntAtLeast(1),
+		ConflictsWith: []string{"name"},
+	}
+	dataSchema["name"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ValidateFunc:  validation.StringIsNotWhiteSpace,
+		ConflictsWith: []string{"build_definition_id"},
+	}
+	dataSchema["path"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validate.Path,
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceBuildDefinitionRead,
+		Schema: dataSchema,
+	}
+}
+
+func dataSourceBuildDefinitionRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	definitionID := d.Get("build_definition_id").(int)
+	if definitionID == 0 {
+		name := d.Get("name").(string)
+		if name == "" {
+			return fmt.Errorf("Either the build_definition_id or the name of the build definition must be configured")
+		}
+
+		var err error
+		definitionID, err = findBuildDefinitionIDByNameAndPath(clients, projectID, name, d.Get("path").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	buildDefinition, err := clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
+		Project:      converter.String(projectID),
+		DefinitionId: converter.Int(definitionID),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return fmt.Errorf("Build definition with ID %d does not exist in project %s", definitionID, projectID)
+		}
+		return fmt.Errorf("Error reading build definition with ID %d: %+v", definitionID, err)
+	}
+
+	flattenBuildDefinition(d, buildDefinition, projectID)
+	d.Set("build_definition_id", definitionID)
+	return nil
+}
+
+// findBuildDefinitionIDByNameAndPath returns the ID of the build definition with the given name. If no path is
+// configured the name must be unique within the project.
+func findBuildDefinitionIDByNameAndPath(clients *client.AggregatedClient, projectID string, name string, path string) (int, error) {
+	definitions, err := getBuildDefinitionReferences(clients, build.GetDefinitionsArgs{
+		Project: converter.String(projectID),
+		Name:    converter.String(name),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Error finding build definition with name %s in project %s: %+v", name, projectID, err)
+	}
+
+	var matches []build.BuildDefinitionReference
+	for _, definition := range definitions {
+		if !strings.EqualFold(converter.ToString(definition.Name, ""), name) {
+			continue
+		}
+		if path != "" && !strings.EqualFold(converter.ToString(definition.Path, ""), path) {
+			continue
+		}
+		matches = append(matches, definition)
+	}
+
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("Build definition with name %s does not exist in project %s", name, projectID)
+	}
+	if len(matches) > 1 {
+		return 0, fmt.Errorf("Multiple build definitions with name %s found in project %s, please specify the path", name, projectID)
+	}
+	return *matches[0].Id, nil
+}
+
+// getBuildDefinitionReferences returns all definitions matching the query, following the continuation tokens
+func getBuildDefinitionReferences(clients *client.AggregatedClient, args build.GetDefinitionsArgs) ([]build.BuildDefinitionReference, error) {
+	var definitions []build.BuildDefinitionReference
+	for {
+		response, err := clients.BuildClient.GetDefinitions(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, response.Value...)
+
+		if response.ContinuationToken == "" {
+			return definitions, nil
+		}
+		args.ContinuationToken = converter.String(response.ContinuationToken)
+	}
+}
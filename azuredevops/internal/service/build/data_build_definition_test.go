@@ -0,0 +1,175 @@
+// +build all data_sources data_build_definition
+// +build !exclude_data_sources !exclude_data_build_definition
+
+package build
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testDataBuildDefinitionProjectID = uuid.New().String()
+
+var testBuildDefinitionReferences = []build.BuildDefinitionReference{
+	{
+		Id:   converter.Int(1),
+		Name: converter.String("Name"),
+		Path: converter.String(`\TeamA`),
+	},
+	{
+		Id:   converter.Int(2),
+		Name: converter.String("Name"),
+		Path: converter.String(`\TeamB`),
+	},
+	{
+		Id:   converter.Int(3),
+		Name: converter.String("Name-Nightly"),
+		Path: converter.String(`\TeamB`),
+	},
+}
+
+func mockGetDefinitionsByName(buildClient *azdosdkmocks.MockBuildClient, ctx context.Context) {
+	buildClient.
+		EXPECT().
+		GetDefinitions(ctx, build.GetDefinitionsArgs{
+			Project: &testDataBuildDefinitionProjectID,
+			Name:    converter.String("Name"),
+		}).
+		Return(&build.GetDefinitionsResponseValue{
+			Value: testBuildDefinitionReferences,
+		}, nil).
+		Times(1)
+}
+
+// verifies that the build definition is looked up by name and path and that its attributes are exposed
+func TestDataSourceBuildDefinition_Read_FindsDefinitionByNameAndPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, DataBuildDefinition().Schema, nil)
+	resourceData.Set("project_id", testDataBuildDefinitionProjectID)
+	resourceData.Set("name", "Name")
+	resourceData.Set("path", `\teamb`)
+
+	mockGetDefinitionsByName(buildClient, clients.Ctx)
+
+	foundDefinition := build.BuildDefinition{
+		Id:       converter.Int(2),
+		Revision: converter.Int(1),
+		Name:     converter.String("Name"),
+		Path:     converter.String(`\TeamB`),
+		Repository: &build.BuildRepository{
+			Url:           converter.String("https://github.com/RepoId.git"),
+			Id:            converter.String("RepoId"),
+			Name:          converter.String("RepoId"),
+			DefaultBranch: converter.String("RepoBranchName"),
+			Type:          converter.String("GitHub"),
+			Properties: &map[string]string{
+				"connectedServiceId": "ServiceConnectionID",
+				"apiUrl":             "https://api.github.com/repos/RepoId",
+				"reportBuildStatus":  "true",
+			},
+		},
+		Process: &build.YamlProcess{
+			YamlFilename: converter.String("YamlFilename"),
+		},
+		Queue: &build.AgentPoolQueue{
+			Id:   converter.Int(1),
+			Name: converter.String("BuildPoolName"),
+			Pool: &build.TaskAgentPoolReference{
+				Id:   converter.Int(1),
+				Name: converter.String("BuildPoolName"),
+			},
+		},
+	}
+	buildClient.
+		EXPECT().
+		GetDefinition(clients.Ctx, build.GetDefinitionArgs{
+			Project:      &testDataBuildDefinitionProjectID,
+			DefinitionId: converter.Int(2),
+		}).
+		Return(&foundDefinition, nil).
+		Times(1)
+
+	err := dataSourceBuildDefinitionRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "2", resourceData.Id())
+	require.Equal(t, 2, resourceData.Get("build_definition_id"))
+	require.Equal(t, "YamlFilename", resourceData.Get("repository.0.yml_path"))
+	require.Equal(t, "BuildPoolName", resourceData.Get("agent_pool_name"))
+}
+
+// verifies that an ambiguous name is reported instead of picking an arbitrary build definition
+func TestDataSourceBuildDefinition_Read_FailsIfNameIsAmbiguous(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, DataBuildDefinition().Schema, nil)
+	resourceData.Set("project_id", testDataBuildDefinitionProjectID)
+	resourceData.Set("name", "Name")
+
+	mockGetDefinitionsByName(buildClient, clients.Ctx)
+	buildClient.
+		EXPECT().
+		GetDefinition(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := dataSourceBuildDefinitionRead(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Multiple build definitions with name Name found")
+}
+
+// verifies that all pages of build definitions are returned
+func TestDataSourceBuildDefinitions_Read_FollowsContinuationToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, DataBuildDefinitions().Schema, nil)
+	resourceData.Set("project_id", testDataBuildDefinitionProjectID)
+	resourceData.Set("path", `\TeamB`)
+
+	args := build.GetDefinitionsArgs{
+		Project: &testDataBuildDefinitionProjectID,
+		Path:    converter.String(`\TeamB`),
+	}
+	buildClient.
+		EXPECT().
+		GetDefinitions(clients.Ctx, args).
+		Return(&build.GetDefinitionsResponseValue{
+			Value:             testBuildDefinitionReferences[1:2],
+			ContinuationToken: "next",
+		}, nil).
+		Times(1)
+	args.ContinuationToken = converter.String("next")
+	buildClient.
+		EXPECT().
+		GetDefinitions(clients.Ctx, args).
+		Return(&build.GetDefinitionsResponseValue{
+			Value: testBuildDefinitionReferences[2:],
+		}, nil).
+		Times(1)
+
+	err := dataSourceBuildDefinitionsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, 2, resourceData.Get("definitions.#"))
+	require.Equal(t, 2, resourceData.Get("definitions.0.id"))
+	require.Equal(t, "Name-Nightly", resourceData.Get("definitions.1.name"))
+}
@@ -0,0 +1,150 @@
+package build
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/validate"
+)
+
+// DataBuildDefinitions schema and implementation for build definitions data source
+func DataBuildDefinitions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBuildDefinitionsRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.Path,
+			},
+			"repository_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"repository_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"repository_id"},
+				ValidateFunc: validation.StringInSlice([]string{"GitHub", "TfsGit", "Bitbucket", "GitHubEnterprise"}, false),
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"definitions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"revision": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"queue_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBuildDefinitionsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	path := d.Get("path").(string)
+	repositoryID := d.Get("repository_id").(string)
+	repositoryType := d.Get("repository_type").(string)
+	name := d.Get("name").(string)
+
+	args := build.GetDefinitionsArgs{
+		Project: converter.String(projectID),
+	}
+	if path != "" {
+		args.Path = converter.String(path)
+	}
+	if repositoryID != "" {
+		args.RepositoryId = converter.String(repositoryID)
+		// the service only filters by repository if the type of the repository is known
+		if repositoryType == "" {
+			repositoryType = "TfsGit"
+		}
+		args.RepositoryType = converter.String(repositoryType)
+	}
+	if name != "" {
+		args.Name = converter.String(name)
+	}
+
+	definitions, err := getBuildDefinitionReferences(clients, args)
+	if err != nil {
+		return fmt.Errorf("Error finding build definitions in project %s. Error: %v", projectID, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] build definitions from project %s", len(definitions), projectID)
+
+	h := sha1.New()
+	if _, err := h.Write([]byte(strings.Join([]string{projectID, path, repositoryID, repositoryType, name}, "#"))); err != nil {
+		return fmt.Errorf("Unable to compute hash for build definitions filter: %v", err)
+	}
+	d.SetId("definitions#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+
+	err = d.Set("definitions", flattenBuildDefinitionReferences(definitions))
+	if err != nil {
+		return fmt.Errorf("Error setting build definitions of project %s: %v", projectID, err)
+	}
+	return nil
+}
+
+func flattenBuildDefinitionReferences(definitions []build.BuildDefinitionReference) []interface{} {
+	results := make([]interface{}, 0, len(definitions))
+	for _, definition := range definitions {
+		output := map[string]interface{}{
+			"name": converter.ToString(definition.Name, ""),
+			"path": converter.ToString(definition.Path, ""),
+			"url":  converter.ToString(definition.Url, ""),
+		}
+		if definition.Id != nil {
+			output["id"] = *definition.Id
+		}
+		if definition.Revision != nil {
+			output["revision"] = *definition.Revision
+		}
+		if definition.QueueStatus != nil {
+			output["queue_status"] = string(*definition.QueueStatus)
+		}
+		results = append(results, output)
+	}
+	return results
+}
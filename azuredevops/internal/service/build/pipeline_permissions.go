@@ -0,0 +1,58 @@
+package build
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+)
+
+// The pipeline permissions of a resource are not part of the SDK. They define which pipelines
+// are authorized to use a protected resource like a service endpoint or an environment.
+var pipelinePermissionsLocationID = uuid.MustParse("b5b9a4a4-e6cd-4096-853c-ab7d8b0c4eb2")
+
+const pipelinePermissionsAPIVersion = "5.1-preview.1"
+
+type pipelinePermissions struct {
+	Resource     *pipelinePermissionsResource `json:"resource,omitempty"`
+	Pipelines    *[]pipelinePermission        `json:"pipelines,omitempty"`
+	AllPipelines *pipelinePermission          `json:"allPipelines,omitempty"`
+}
+
+type pipelinePermissionsResource struct {
+	Type *string `json:"type,omitempty"`
+	Id   *string `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+type pipelinePermission struct {
+	Id         *int  `json:"id,omitempty"`
+	Authorized *bool `json:"authorized,omitempty"`
+}
+
+// using: GET https://dev.azure.com/{organization}/{project}/_apis/pipelines/pipelinePermissions/{resourceType}/{resourceId}?api-version=5.1-preview.1
+func getPipelinePermissionsForResource(clients *client.AggregatedClient, projectID string, resourceType string, resourceID string) (*pipelinePermissions, error) {
+	return sendPipelinePermissions(clients, http.MethodGet, projectID, resourceType, resourceID, nil)
+}
+
+// using: PATCH https://dev.azure.com/{organization}/{project}/_apis/pipelines/pipelinePermissions/{resourceType}/{resourceId}?api-version=5.1-preview.1
+func updatePipelinePermissionsForResource(clients *client.AggregatedClient, projectID string, resourceType string, resourceID string, permissions *pipelinePermissions) (*pipelinePermissions, error) {
+	return sendPipelinePermissions(clients, http.MethodPatch, projectID, resourceType, resourceID, permissions)
+}
+
+func sendPipelinePermissions(clients *client.AggregatedClient, method string, projectID string, resourceType string, resourceID string, body interface{}) (*pipelinePermissions, error) {
+	var permissions pipelinePermissions
+	err := utils.SendRestRequest(clients.Ctx, clients.BuildClient, &utils.RestRequest{
+		Method:     method,
+		LocationID: pipelinePermissionsLocationID,
+		APIVersion: pipelinePermissionsAPIVersion,
+		RouteValues: map[string]string{
+			"project":      projectID,
+			"resourceType": resourceType,
+			"resourceId":   resourceID,
+		},
+		Body: body,
+	}, &permissions)
+	return &permissions, err
+}
@@ -1,6 +1,7 @@
 package build
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/model"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
@@ -19,6 +21,17 @@ import (
 )
 
 const (
+	designerProcessType  = 1
+	agentPhaseTargetType = 1
+)
+
+// The days of a schedule in the order of the bits of the ScheduleDays flags enum
+var scheduleDayNames = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+const (
+	bdPhase                 = "phase"
+	bdSchedules             = "schedules"
+	bdTask                  = "task"
 	bdVariable              = "variable"
 	bdVariableName          = "name"
 	bdVariableValue         = "value"
@@ -65,6 +78,77 @@ func ResourceBuildDefinition() *schema.Resource {
 		},
 	}
 
+	taskSchema := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"task_id": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.IsUUID,
+				},
+				"version": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"definition_type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "task",
+					ValidateFunc: validation.StringInSlice([]string{"task", "metaTask"}, false),
+				},
+				"display_name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"ref_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"enabled": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"continue_on_error": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"condition": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "succeeded()",
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"timeout_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"inputs": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"environment": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+
 	return &schema.Resource{
 		Create:   resourceBuildDefinitionCreate,
 		Read:     resourceBuildDefinitionRead,
@@ -136,9 +220,129 @@ func ResourceBuildDefinition() *schema.Resource {
 				},
 			},
 			"agent_pool_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				ConflictsWith: []string{"agent_pool_id", "queue_id"},
+			},
+			"agent_pool_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"agent_pool_name", "queue_id"},
+			},
+			"queue_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"agent_pool_name", "agent_pool_id"},
+			},
+			"build_number_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"queue_status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(build.DefinitionQueueStatusValues.Enabled),
+					string(build.DefinitionQueueStatusValues.Paused),
+					string(build.DefinitionQueueStatusValues.Disabled),
+				}, false),
+			},
+			"badge_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"retention_rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"branch_filter": branchFilter,
+						"days_to_keep": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"minimum_to_keep": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"delete_build_record": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"delete_test_results": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"artifacts_to_delete": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+						},
+						"artifact_types_to_delete": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+						},
+					},
+				},
+			},
+			"vm_image": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				ConflictsWith: []string{"repository.0.yml_path"},
+			},
+			"demands": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"job_timeout_in_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"job_cancel_timeout_in_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 60),
+			},
+			"job_authorization_scope": {
 				Type:     schema.TypeString,
 				Optional: true,
-				Default:  "Hosted Ubuntu 1604",
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(build.BuildAuthorizationScopeValues.ProjectCollection),
+					string(build.BuildAuthorizationScopeValues.Project),
+				}, false),
 			},
 			"repository": {
 				Type:     schema.TypeList,
@@ -149,7 +353,7 @@ func ResourceBuildDefinition() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"yml_path": {
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
 						},
 						"repo_id": {
 							Type:     schema.TypeString,
@@ -188,6 +392,32 @@ func ResourceBuildDefinition() *schema.Resource {
 					},
 				},
 			},
+			bdPhase: {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"repository.0.yml_path"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"ref_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"condition": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "succeeded()",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						bdTask: taskSchema,
+					},
+				},
+			},
 			"ci_trigger": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -295,6 +525,70 @@ func ResourceBuildDefinition() *schema.Resource {
 					},
 				},
 			},
+			"build_completion_trigger": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"build_definition_id": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"branch_filter": branchFilter,
+						"requires_successful_build": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+			bdSchedules: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"branch_filter": branchFilter,
+						"days_to_build": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(scheduleDayNames, false),
+							},
+						},
+						"start_hours": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 23),
+						},
+						"start_minutes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 59),
+						},
+						"time_zone": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "UTC",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"schedule_only_with_changes": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"schedule_job_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -309,6 +603,10 @@ func resourceBuildDefinitionCreate(d *schema.ResourceData, m interface{}) error
 	if err != nil {
 		return fmt.Errorf("error creating resource Build Definition: %+v", err)
 	}
+	buildDefinition.Queue, err = resolveBuildDefinitionQueue(clients, d, projectID)
+	if err != nil {
+		return fmt.Errorf("error creating resource Build Definition: %+v", err)
+	}
 
 	createdBuildDefinition, err := createBuildDefinition(clients, buildDefinition, projectID)
 	if err != nil {
@@ -326,9 +624,25 @@ func flattenBuildDefinition(d *schema.ResourceData, buildDefinition *build.Build
 	d.Set("name", *buildDefinition.Name)
 	d.Set("path", *buildDefinition.Path)
 	d.Set("repository", flattenRepository(buildDefinition))
+	d.Set(bdPhase, flattenBuildDefinitionPhases(d, buildDefinition))
 
-	if buildDefinition.Queue != nil && buildDefinition.Queue.Pool != nil {
-		d.Set("agent_pool_name", *buildDefinition.Queue.Pool.Name)
+	if buildDefinition.Queue != nil {
+		d.Set("queue_id", converter.ToInt(buildDefinition.Queue.Id, 0))
+		if buildDefinition.Queue.Pool != nil {
+			d.Set("agent_pool_name", converter.ToString(buildDefinition.Queue.Pool.Name, ""))
+			d.Set("agent_pool_id", converter.ToInt(buildDefinition.Queue.Pool.Id, 0))
+		}
+	}
+	d.Set("vm_image", flattenBuildDefinitionAgentSpecification(buildDefinition))
+	d.Set("demands", flattenBuildDefinitionDemands(buildDefinition.Demands))
+	if buildDefinition.JobTimeoutInMinutes != nil {
+		d.Set("job_timeout_in_minutes", *buildDefinition.JobTimeoutInMinutes)
+	}
+	if buildDefinition.JobCancelTimeoutInMinutes != nil {
+		d.Set("job_cancel_timeout_in_minutes", *buildDefinition.JobCancelTimeoutInMinutes)
+	}
+	if buildDefinition.JobAuthorizationScope != nil {
+		d.Set("job_authorization_scope", string(*buildDefinition.JobAuthorizationScope))
 	}
 
 	d.Set("variable_groups", flattenVariableGroups(buildDefinition))
@@ -340,6 +654,16 @@ func flattenBuildDefinition(d *schema.ResourceData, buildDefinition *build.Build
 
 		yamlPrTrigger := hasSettingsSourceType(buildDefinition.Triggers, build.DefinitionTriggerTypeValues.PullRequest, 2)
 		d.Set("pull_request_trigger", flattenBuildDefinitionTriggers(buildDefinition.Triggers, yamlPrTrigger, build.DefinitionTriggerTypeValues.PullRequest))
+
+		d.Set("build_completion_trigger", flattenBuildDefinitionTriggers(buildDefinition.Triggers, false, build.DefinitionTriggerTypeValues.BuildCompletion))
+		d.Set(bdSchedules, flattenBuildDefinitionSchedules(buildDefinition.Triggers))
+	}
+
+	d.Set("build_number_format", converter.ToString(buildDefinition.BuildNumberFormat, ""))
+	d.Set("badge_enabled", converter.ToBool(buildDefinition.BadgeEnabled, false))
+	d.Set("retention_rule", flattenBuildDefinitionRetentionRules(buildDefinition.RetentionRules))
+	if buildDefinition.QueueStatus != nil {
+		d.Set("queue_status", string(*buildDefinition.QueueStatus))
 	}
 
 	revision := 0
@@ -447,6 +771,10 @@ func resourceBuildDefinitionUpdate(d *schema.ResourceData, m interface{}) error
 	if err != nil {
 		return err
 	}
+	buildDefinition.Queue, err = resolveBuildDefinitionQueue(clients, d, projectID)
+	if err != nil {
+		return err
+	}
 
 	updatedBuildDefinition, err := clients.BuildClient.UpdateDefinition(m.(*client.AggregatedClient).Ctx, build.UpdateDefinitionArgs{
 		Definition:   buildDefinition,
@@ -484,7 +812,9 @@ func flattenRepository(buildDefinition *build.BuildDefinition) interface{} {
 	// available from the compiler is `interface{}` so we can probe for known
 	// implementations
 	if processMap, ok := buildDefinition.Process.(map[string]interface{}); ok {
-		yamlFilePath = processMap["yamlFilename"].(string)
+		if yamlFilename, ok := processMap["yamlFilename"].(string); ok {
+			yamlFilePath = yamlFilename
+		}
 	}
 	if yamlProcess, ok := buildDefinition.Process.(*build.YamlProcess); ok {
 		yamlFilePath = *yamlProcess.YamlFilename
@@ -603,6 +933,23 @@ func flattenBuildDefinitionPullRequestTrigger(m interface{}, isYaml bool) interf
 	return nil
 }
 
+func flattenBuildDefinitionBuildCompletionTrigger(m interface{}) interface{} {
+	if ms, ok := m.(map[string]interface{}); ok {
+		branchFilters, _ := ms["branchFilters"].([]interface{})
+		requiresSuccessfulBuild, _ := ms["requiresSuccessfulBuild"].(bool)
+		definitionID := 0
+		if definition, ok := ms["definition"].(map[string]interface{}); ok {
+			definitionID = toInt(definition["id"])
+		}
+		return map[string]interface{}{
+			"build_definition_id":       definitionID,
+			"branch_filter":             flattenBuildDefinitionBranchOrPathFilter(branchFilters),
+			"requires_successful_build": requiresSuccessfulBuild,
+		}
+	}
+	return nil
+}
+
 func flattenBuildDefinitionTrigger(m interface{}, isYaml bool, t build.DefinitionTriggerType) interface{} {
 	if ms, ok := m.(map[string]interface{}); ok {
 		if ms["triggerType"].(string) != string(t) {
@@ -613,6 +960,8 @@ func flattenBuildDefinitionTrigger(m interface{}, isYaml bool, t build.Definitio
 			return flattenBuildDefinitionContinuousIntegrationTrigger(ms, isYaml)
 		case build.DefinitionTriggerTypeValues.PullRequest:
 			return flattenBuildDefinitionPullRequestTrigger(ms, isYaml)
+		case build.DefinitionTriggerTypeValues.BuildCompletion:
+			return flattenBuildDefinitionBuildCompletionTrigger(ms)
 		}
 	}
 	return nil
@@ -786,6 +1135,15 @@ func expandBuildDefinitionTrigger(d map[string]interface{}, t build.DefinitionTr
 			vs["autoCancel"] = override["autoCancel"]
 		}
 		return vs
+	case build.DefinitionTriggerTypeValues.BuildCompletion:
+		return map[string]interface{}{
+			"branchFilters": expandBuildDefinitionBranchOrPathFilterSet(d["branch_filter"].(*schema.Set)),
+			"definition": map[string]interface{}{
+				"id": d["build_definition_id"].(int),
+			},
+			"requiresSuccessfulBuild": d["requires_successful_build"].(bool),
+			"triggerType":             string(t),
+		}
 	}
 	return nil
 }
@@ -800,6 +1158,117 @@ func expandBuildDefinitionTriggerList(d []interface{}, t build.DefinitionTrigger
 	return vs
 }
 
+// All schedules of a build definition are stored in a single trigger of type schedule
+func expandBuildDefinitionScheduleTrigger(d []interface{}) interface{} {
+	schedules := make([]interface{}, 0, len(d))
+	for _, v := range d {
+		if val, ok := v.(map[string]interface{}); ok {
+			schedules = append(schedules, expandBuildDefinitionSchedule(val))
+		}
+	}
+	if len(schedules) == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"schedules":   schedules,
+		"triggerType": string(build.DefinitionTriggerTypeValues.Schedule),
+	}
+}
+
+func expandBuildDefinitionSchedule(d map[string]interface{}) map[string]interface{} {
+	schedule := map[string]interface{}{
+		"branchFilters":           expandBuildDefinitionBranchOrPathFilterSet(d["branch_filter"].(*schema.Set)),
+		"daysToBuild":             expandScheduleDays(d["days_to_build"].(*schema.Set)),
+		"startHours":              d["start_hours"].(int),
+		"startMinutes":            d["start_minutes"].(int),
+		"timeZoneId":              d["time_zone"].(string),
+		"scheduleOnlyWithChanges": d["schedule_only_with_changes"].(bool),
+	}
+	if jobID, ok := d["schedule_job_id"].(string); ok && jobID != "" {
+		schedule["scheduleJobId"] = jobID
+	}
+	return schedule
+}
+
+// expandScheduleDays returns the flags enum of the days as bit mask, which is accepted by the service
+func expandScheduleDays(days *schema.Set) int {
+	mask := 0
+	for _, day := range tfhelper.ExpandStringSet(days) {
+		for i, name := range scheduleDayNames {
+			if name == day {
+				mask |= 1 << uint(i)
+			}
+		}
+	}
+	return mask
+}
+
+func flattenBuildDefinitionSchedules(m *[]interface{}) []interface{} {
+	schedules := []interface{}{}
+	for _, d := range *m {
+		ms, ok := d.(map[string]interface{})
+		if !ok || !strings.EqualFold(ms["triggerType"].(string), string(build.DefinitionTriggerTypeValues.Schedule)) {
+			continue
+		}
+		values, ok := ms["schedules"].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, v := range values {
+			if schedule, ok := v.(map[string]interface{}); ok {
+				schedules = append(schedules, flattenBuildDefinitionSchedule(schedule))
+			}
+		}
+	}
+	return schedules
+}
+
+func flattenBuildDefinitionSchedule(m map[string]interface{}) map[string]interface{} {
+	branchFilters, _ := m["branchFilters"].([]interface{})
+	scheduleOnlyWithChanges, _ := m["scheduleOnlyWithChanges"].(bool)
+	timeZone, _ := m["timeZoneId"].(string)
+	jobID, _ := m["scheduleJobId"].(string)
+	return map[string]interface{}{
+		"branch_filter":              flattenBuildDefinitionBranchOrPathFilter(branchFilters),
+		"days_to_build":              flattenScheduleDays(m["daysToBuild"]),
+		"start_hours":                toInt(m["startHours"]),
+		"start_minutes":              toInt(m["startMinutes"]),
+		"time_zone":                  timeZone,
+		"schedule_only_with_changes": scheduleOnlyWithChanges,
+		"schedule_job_id":            jobID,
+	}
+}
+
+// flattenScheduleDays handles the bit mask as well as the textual representation of the flags enum,
+// e.g. "monday, friday" or "all", returned by the service
+func flattenScheduleDays(v interface{}) []interface{} {
+	mask := 0
+	if value, ok := v.(string); ok {
+		for _, day := range strings.Split(value, ",") {
+			day = strings.TrimSpace(day)
+			if strings.EqualFold(day, string(build.ScheduleDaysValues.All)) {
+				mask = 1<<uint(len(scheduleDayNames)) - 1
+				continue
+			}
+			for i, name := range scheduleDayNames {
+				if len(day) >= 3 && strings.EqualFold(name, day[:3]) {
+					mask |= 1 << uint(i)
+				}
+			}
+		}
+	} else {
+		mask = toInt(v)
+	}
+
+	days := []interface{}{}
+	for i, name := range scheduleDayNames {
+		if mask&(1<<uint(i)) != 0 {
+			days = append(days, name)
+		}
+	}
+	return days
+}
+
 func expandVariableGroups(d *schema.ResourceData) *[]build.VariableGroup {
 	variableGroupsInterface := d.Get("variable_groups").(*schema.Set).List()
 	variableGroups := make([]build.VariableGroup, len(variableGroupsInterface))
@@ -888,7 +1357,16 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 		build.DefinitionTriggerTypeValues.PullRequest,
 	)
 
+	buildCompletionTriggers := expandBuildDefinitionTriggerList(
+		d.Get("build_completion_trigger").([]interface{}),
+		build.DefinitionTriggerTypeValues.BuildCompletion,
+	)
+
 	buildTriggers := append(ciTriggers, pullRequestTriggers...)
+	buildTriggers = append(buildTriggers, buildCompletionTriggers...)
+	if scheduleTrigger := expandBuildDefinitionScheduleTrigger(d.Get(bdSchedules).([]interface{})); scheduleTrigger != nil {
+		buildTriggers = append(buildTriggers, scheduleTrigger)
+	}
 
 	// Look for the ID. This may not exist if we are within the context of a "create" operation,
 	// so it is OK if it is missing.
@@ -905,6 +1383,24 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 		return nil, "", fmt.Errorf("Error expanding varibles: %+v", err)
 	}
 
+	var process interface{}
+	phases := d.Get(bdPhase).([]interface{})
+	if len(phases) > 0 {
+		process = expandBuildDefinitionDesignerProcess(phases, d.Get("vm_image").(string))
+	} else {
+		ymlPath := repository["yml_path"].(string)
+		if ymlPath == "" {
+			return nil, "", fmt.Errorf("Either the yml_path of the repository or at least one phase must be configured")
+		}
+		process = &build.YamlProcess{
+			YamlFilename: converter.String(ymlPath),
+		}
+	}
+
+	queueStatus := build.DefinitionQueueStatusValues.Enabled
+	if status := d.Get("queue_status").(string); status != "" {
+		queueStatus = build.DefinitionQueueStatus(status)
+	}
 	buildDefinition := build.BuildDefinition{
 		Id:       buildDefinitionReference,
 		Name:     converter.String(d.Get("name").(string)),
@@ -922,15 +1418,33 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 				"reportBuildStatus":  strconv.FormatBool(repository["report_build_status"].(bool)),
 			},
 		},
-		Process: &build.YamlProcess{
-			YamlFilename: converter.String(repository["yml_path"].(string)),
-		},
-		QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
+		Process:        process,
+		QueueStatus:    &queueStatus,
+		BadgeEnabled:   converter.Bool(d.Get("badge_enabled").(bool)),
+		RetentionRules: expandBuildDefinitionRetentionRules(d.Get("retention_rule").([]interface{})),
 		Type:           &build.DefinitionTypeValues.Build,
 		Quality:        &build.DefinitionQualityValues.Definition,
 		VariableGroups: expandVariableGroups(d),
 		Variables:      variables,
 		Triggers:       &buildTriggers,
+		Demands:        expandBuildDefinitionDemands(d.Get("demands").([]interface{})),
+	}
+
+	// The job settings are only sent if they are configured, otherwise the service uses its defaults.
+	// A job timeout of 0 is a valid setting for the maximum timeout of the agent pool.
+	if jobTimeout, ok := d.GetOkExists("job_timeout_in_minutes"); ok {
+		buildDefinition.JobTimeoutInMinutes = converter.Int(jobTimeout.(int))
+	}
+	if jobCancelTimeout, ok := d.GetOk("job_cancel_timeout_in_minutes"); ok {
+		buildDefinition.JobCancelTimeoutInMinutes = converter.Int(jobCancelTimeout.(int))
+	}
+	if jobAuthorizationScope, ok := d.GetOk("job_authorization_scope"); ok {
+		scope := build.BuildAuthorizationScope(jobAuthorizationScope.(string))
+		buildDefinition.JobAuthorizationScope = &scope
+	}
+
+	if buildNumberFormat := d.Get("build_number_format").(string); buildNumberFormat != "" {
+		buildDefinition.BuildNumberFormat = converter.String(buildNumberFormat)
 	}
 
 	if agentPoolName, ok := d.GetOk("agent_pool_name"); ok {
@@ -940,11 +1454,395 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 				Name: converter.StringFromInterface(agentPoolName),
 			},
 		}
+		if poolID := d.Get("agent_pool_id").(int); poolID > 0 {
+			buildDefinition.Queue.Pool.Id = converter.Int(poolID)
+		}
+		if queueID := d.Get("queue_id").(int); queueID > 0 {
+			buildDefinition.Queue.Id = converter.Int(queueID)
+		}
 	}
 
 	return &buildDefinition, projectID, nil
 }
 
+// expandBuildDefinitionRetentionRules returns nil if no rules are configured, so the retention settings of the
+// project are used
+func expandBuildDefinitionRetentionRules(d []interface{}) *[]build.RetentionPolicy {
+	if len(d) == 0 {
+		return nil
+	}
+	rules := make([]build.RetentionPolicy, 0, len(d))
+	for _, v := range d {
+		rule, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		branches := tfhelper.ExpandStringList(expandBuildDefinitionBranchOrPathFilterSet(rule["branch_filter"].(*schema.Set)))
+		artifacts := tfhelper.ExpandStringList(rule["artifacts_to_delete"].([]interface{}))
+		artifactTypes := tfhelper.ExpandStringList(rule["artifact_types_to_delete"].([]interface{}))
+		rules = append(rules, build.RetentionPolicy{
+			Branches:              &branches,
+			DaysToKeep:            converter.Int(rule["days_to_keep"].(int)),
+			MinimumToKeep:         converter.Int(rule["minimum_to_keep"].(int)),
+			DeleteBuildRecord:     converter.Bool(rule["delete_build_record"].(bool)),
+			DeleteTestResults:     converter.Bool(rule["delete_test_results"].(bool)),
+			Artifacts:             &artifacts,
+			ArtifactTypesToDelete: &artifactTypes,
+		})
+	}
+	return &rules
+}
+
+func flattenBuildDefinitionRetentionRules(rules *[]build.RetentionPolicy) []interface{} {
+	if rules == nil {
+		return nil
+	}
+	results := make([]interface{}, 0, len(*rules))
+	for _, rule := range *rules {
+		branches := flattenStringList(rule.Branches)
+		artifacts := flattenStringList(rule.Artifacts)
+		artifactTypes := flattenStringList(rule.ArtifactTypesToDelete)
+		results = append(results, map[string]interface{}{
+			"branch_filter":            flattenBuildDefinitionBranchOrPathFilter(branches),
+			"days_to_keep":             converter.ToInt(rule.DaysToKeep, 0),
+			"minimum_to_keep":          converter.ToInt(rule.MinimumToKeep, 0),
+			"delete_build_record":      converter.ToBool(rule.DeleteBuildRecord, false),
+			"delete_test_results":      converter.ToBool(rule.DeleteTestResults, false),
+			"artifacts_to_delete":      artifacts,
+			"artifact_types_to_delete": artifactTypes,
+		})
+	}
+	return results
+}
+
+// The designer process is expanded into the raw JSON structure of the REST API, because the
+// models of the SDK do not match the payload sent by the service, e.g. the job authorization
+// scope of a phase is returned as a number.
+func expandBuildDefinitionDesignerProcess(phases []interface{}, vmImage string) map[string]interface{} {
+	expandedPhases := make([]interface{}, 0, len(phases))
+	for _, phase := range phases {
+		if phaseMap, ok := phase.(map[string]interface{}); ok {
+			expandedPhases = append(expandedPhases, expandBuildDefinitionPhase(phaseMap))
+		}
+	}
+	process := map[string]interface{}{
+		"type":   designerProcessType,
+		"phases": expandedPhases,
+	}
+	if vmImage != "" {
+		process["target"] = map[string]interface{}{
+			"agentSpecification": map[string]interface{}{
+				"identifier": vmImage,
+			},
+		}
+	}
+	return process
+}
+
+// flattenBuildDefinitionAgentSpecification returns the hosted image a designer process runs on
+func flattenBuildDefinitionAgentSpecification(buildDefinition *build.BuildDefinition) string {
+	processMap := getBuildDefinitionProcessMap(buildDefinition)
+	if processMap == nil || toInt(processMap["type"]) != designerProcessType {
+		return ""
+	}
+	target, _ := processMap["target"].(map[string]interface{})
+	agentSpecification, _ := target["agentSpecification"].(map[string]interface{})
+	identifier, _ := agentSpecification["identifier"].(string)
+	return identifier
+}
+
+func expandBuildDefinitionDemands(d []interface{}) *[]interface{} {
+	demands := make([]interface{}, 0, len(d))
+	for _, demand := range d {
+		if value, ok := demand.(string); ok {
+			demands = append(demands, value)
+		}
+	}
+	return &demands
+}
+
+// flattenBuildDefinitionDemands handles demands returned as plain strings, e.g. "Agent.OS -equals Linux",
+// as well as demands returned as objects with name and value
+func flattenBuildDefinitionDemands(demands *[]interface{}) []interface{} {
+	if demands == nil {
+		return nil
+	}
+	results := make([]interface{}, 0, len(*demands))
+	for _, demand := range *demands {
+		switch value := demand.(type) {
+		case string:
+			results = append(results, value)
+		case map[string]interface{}:
+			name, _ := value["name"].(string)
+			if demandValue, ok := value["value"].(string); ok && demandValue != "" {
+				name = fmt.Sprintf("%s -equals %s", name, demandValue)
+			}
+			results = append(results, name)
+		}
+	}
+	return results
+}
+
+// defaultAgentPoolName is the name of the Microsoft-hosted agent pool, which is available in every project
+const defaultAgentPoolName = "Azure Pipelines"
+
+// resolveBuildDefinitionQueue validates that the configured queue exists in the project and returns a reference
+// to it. Because all attributes identifying the queue are computed, the attribute changed by the user takes
+// precedence over the values already known from the state.
+func resolveBuildDefinitionQueue(clients *client.AggregatedClient, d *schema.ResourceData, projectID string) (*build.AgentPoolQueue, error) {
+	queueID := d.Get("queue_id").(int)
+	poolID := d.Get("agent_pool_id").(int)
+	poolName := d.Get("agent_pool_name").(string)
+
+	switch {
+	case d.HasChange("queue_id") && queueID > 0:
+		return getBuildDefinitionQueueByID(clients, projectID, queueID)
+	case d.HasChange("agent_pool_id") && poolID > 0:
+		return getBuildDefinitionQueueByPoolID(clients, projectID, poolID)
+	case d.HasChange("agent_pool_name") && poolName != "":
+		return getBuildDefinitionQueueByName(clients, projectID, poolName)
+	case queueID > 0:
+		return getBuildDefinitionQueueByID(clients, projectID, queueID)
+	case poolID > 0:
+		return getBuildDefinitionQueueByPoolID(clients, projectID, poolID)
+	case poolName != "":
+		return getBuildDefinitionQueueByName(clients, projectID, poolName)
+	}
+	return getBuildDefinitionQueueByName(clients, projectID, defaultAgentPoolName)
+}
+
+func getBuildDefinitionQueueByID(clients *client.AggregatedClient, projectID string, queueID int) (*build.AgentPoolQueue, error) {
+	queue, err := clients.TaskAgentClient.GetAgentQueue(clients.Ctx, taskagent.GetAgentQueueArgs{
+		Project: converter.String(projectID),
+		QueueId: converter.Int(queueID),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return nil, fmt.Errorf("Agent queue with ID %d does not exist in project %s", queueID, projectID)
+		}
+		return nil, fmt.Errorf("Error reading agent queue with ID %d: %+v", queueID, err)
+	}
+	if queue == nil {
+		return nil, fmt.Errorf("Agent queue with ID %d does not exist in project %s", queueID, projectID)
+	}
+	return toBuildDefinitionQueue(queue), nil
+}
+
+func getBuildDefinitionQueueByPoolID(clients *client.AggregatedClient, projectID string, poolID int) (*build.AgentPoolQueue, error) {
+	queues, err := clients.TaskAgentClient.GetAgentQueues(clients.Ctx, taskagent.GetAgentQueuesArgs{
+		Project: converter.String(projectID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading agent queues of project %s: %+v", projectID, err)
+	}
+	if queues != nil {
+		for _, queue := range *queues {
+			if queue.Pool != nil && converter.ToInt(queue.Pool.Id, 0) == poolID {
+				return toBuildDefinitionQueue(&queue), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("No agent queue for agent pool with ID %d exists in project %s", poolID, projectID)
+}
+
+func getBuildDefinitionQueueByName(clients *client.AggregatedClient, projectID string, name string) (*build.AgentPoolQueue, error) {
+	queues, err := clients.TaskAgentClient.GetAgentQueuesByNames(clients.Ctx, taskagent.GetAgentQueuesByNamesArgs{
+		Project:    converter.String(projectID),
+		QueueNames: &[]string{name},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading agent queue %s: %+v", name, err)
+	}
+	if queues == nil || len(*queues) == 0 {
+		return nil, fmt.Errorf("Agent queue %s does not exist in project %s", name, projectID)
+	}
+	return toBuildDefinitionQueue(&(*queues)[0]), nil
+}
+
+func toBuildDefinitionQueue(queue *taskagent.TaskAgentQueue) *build.AgentPoolQueue {
+	buildQueue := &build.AgentPoolQueue{
+		Id:   queue.Id,
+		Name: queue.Name,
+	}
+	if queue.Pool != nil {
+		buildQueue.Pool = &build.TaskAgentPoolReference{
+			Id:       queue.Pool.Id,
+			Name:     queue.Pool.Name,
+			IsHosted: queue.Pool.IsHosted,
+		}
+	}
+	return buildQueue
+}
+
+func expandBuildDefinitionPhase(d map[string]interface{}) map[string]interface{} {
+	tasks := d[bdTask].([]interface{})
+	steps := make([]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		if taskMap, ok := task.(map[string]interface{}); ok {
+			steps = append(steps, expandBuildDefinitionStep(taskMap))
+		}
+	}
+
+	phase := map[string]interface{}{
+		"name":      d["name"].(string),
+		"condition": d["condition"].(string),
+		"steps":     steps,
+		"target": map[string]interface{}{
+			"type": agentPhaseTargetType,
+			"executionOptions": map[string]interface{}{
+				"type": 0,
+			},
+			"allowScriptsAuthAccessOption": false,
+		},
+	}
+	if refName := d["ref_name"].(string); refName != "" {
+		phase["refName"] = refName
+	}
+	return phase
+}
+
+func expandBuildDefinitionStep(d map[string]interface{}) map[string]interface{} {
+	step := map[string]interface{}{
+		"displayName":      d["display_name"].(string),
+		"enabled":          d["enabled"].(bool),
+		"continueOnError":  d["continue_on_error"].(bool),
+		"alwaysRun":        false,
+		"condition":        d["condition"].(string),
+		"timeoutInMinutes": d["timeout_in_minutes"].(int),
+		"task": map[string]interface{}{
+			"id":             d["task_id"].(string),
+			"versionSpec":    d["version"].(string),
+			"definitionType": d["definition_type"].(string),
+		},
+		"inputs":      expandStringMap(d["inputs"]),
+		"environment": expandStringMap(d["environment"]),
+	}
+	if refName := d["ref_name"].(string); refName != "" {
+		step["refName"] = refName
+	}
+	return step
+}
+
+func expandStringMap(v interface{}) map[string]string {
+	result := map[string]string{}
+	if m, ok := v.(map[string]interface{}); ok {
+		for key, value := range m {
+			result[key] = fmt.Sprint(value)
+		}
+	}
+	return result
+}
+
+// getBuildDefinitionProcessMap returns the process of a build definition as raw JSON structure, regardless
+// of whether it has been returned by the service or been expanded by this provider.
+func getBuildDefinitionProcessMap(buildDefinition *build.BuildDefinition) map[string]interface{} {
+	if processMap, ok := buildDefinition.Process.(map[string]interface{}); ok {
+		return processMap
+	}
+	if buildDefinition.Process == nil {
+		return nil
+	}
+
+	processJSON, err := json.Marshal(buildDefinition.Process)
+	if err != nil {
+		return nil
+	}
+	var processMap map[string]interface{}
+	if err := json.Unmarshal(processJSON, &processMap); err != nil {
+		return nil
+	}
+	return processMap
+}
+
+func flattenBuildDefinitionPhases(d *schema.ResourceData, buildDefinition *build.BuildDefinition) []interface{} {
+	processMap := getBuildDefinitionProcessMap(buildDefinition)
+	if processMap == nil || toInt(processMap["type"]) != designerProcessType {
+		return nil
+	}
+
+	phases, _ := processMap["phases"].([]interface{})
+	results := make([]interface{}, 0, len(phases))
+	for phaseIndex, phase := range phases {
+		phaseMap, ok := phase.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		steps, _ := phaseMap["steps"].([]interface{})
+		tasks := make([]interface{}, 0, len(steps))
+		for stepIndex, step := range steps {
+			if stepMap, ok := step.(map[string]interface{}); ok {
+				tasks = append(tasks, flattenBuildDefinitionStep(d, phaseIndex, stepIndex, stepMap))
+			}
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":      phaseMap["name"],
+			"ref_name":  phaseMap["refName"],
+			"condition": phaseMap["condition"],
+			bdTask:      tasks,
+		})
+	}
+	return results
+}
+
+func flattenBuildDefinitionStep(d *schema.ResourceData, phaseIndex int, stepIndex int, step map[string]interface{}) map[string]interface{} {
+	task := map[string]interface{}{
+		"display_name":       step["displayName"],
+		"ref_name":           step["refName"],
+		"enabled":            step["enabled"],
+		"continue_on_error":  step["continueOnError"],
+		"condition":          step["condition"],
+		"timeout_in_minutes": toInt(step["timeoutInMinutes"]),
+		"environment":        toInterfaceMap(step["environment"]),
+	}
+	if taskReference, ok := step["task"].(map[string]interface{}); ok {
+		task["task_id"] = taskReference["id"]
+		task["version"] = taskReference["versionSpec"]
+		task["definition_type"] = taskReference["definitionType"]
+	}
+
+	// The service returns all inputs of a task including the ones using the default value
+	inputs := tfhelper.FilterMapToStateKeys(d, fmt.Sprintf("%s.%d.%s", bdPhase, phaseIndex, bdTask), stepIndex, "inputs", toInterfaceMap(step["inputs"]))
+	task["inputs"] = inputs
+	return task
+}
+
+func toInterfaceMap(v interface{}) map[string]interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		return value
+	case map[string]string:
+		result := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			result[key] = item
+		}
+		return result
+	}
+	return map[string]interface{}{}
+}
+
+func flattenStringList(values *[]string) []interface{} {
+	if values == nil {
+		return []interface{}{}
+	}
+	results := make([]interface{}, 0, len(*values))
+	for _, value := range *values {
+		results = append(results, value)
+	}
+	return results
+}
+
+func toInt(v interface{}) int {
+	switch value := v.(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	}
+	return 0
+}
+
 /**
  * certain types of build definitions require a service connection to run. This function
  * returns an error if a service connection was needed but not provided
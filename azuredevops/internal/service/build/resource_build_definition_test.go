@@ -5,6 +5,7 @@ package build
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"sort"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
@@ -22,6 +24,11 @@ import (
 
 var testProjectID = uuid.New().String()
 
+const (
+	testQueueID = 5
+	testPoolID  = 7
+)
+
 var manualCiTrigger = map[string]interface{}{
 	"branchFilters": []interface{}{
 		"+develop",
@@ -82,9 +89,44 @@ var yamlPrTrigger = map[string]interface{}{
 	"triggerType":                          "pullRequest",
 }
 
+var scheduleTrigger = map[string]interface{}{
+	"schedules": []interface{}{
+		map[string]interface{}{
+			"branchFilters":           []interface{}{"+master"},
+			"daysToBuild":             5,
+			"startHours":              3,
+			"startMinutes":            30,
+			"timeZoneId":              "W. Europe Standard Time",
+			"scheduleOnlyWithChanges": true,
+			"scheduleJobId":           "5e8e3663-2d1c-482b-9e2d-3d6bcd0a8e3f",
+		},
+		map[string]interface{}{
+			"branchFilters":           []interface{}{"-releases/*"},
+			"daysToBuild":             127,
+			"startHours":              0,
+			"startMinutes":            0,
+			"timeZoneId":              "UTC",
+			"scheduleOnlyWithChanges": false,
+			"scheduleJobId":           "0bb05f1c-7c28-4b4f-b7a4-3b4b6e2ce8e1",
+		},
+	},
+	"triggerType": "schedule",
+}
+
+var buildCompletionTrigger = map[string]interface{}{
+	"branchFilters": []interface{}{"+refs/heads/master"},
+	"definition": map[string]interface{}{
+		"id": 12,
+	},
+	"requiresSuccessfulBuild": true,
+	"triggerType":             "buildCompletion",
+}
+
 var triggerGroups = [][]interface{}{
 	{manualCiTrigger, manualPrTrigger},
 	{yamlCiTrigger, yamlPrTrigger},
+	{scheduleTrigger},
+	{buildCompletionTrigger},
 }
 
 // This definition matches the overall structure of what a configured git repository would
@@ -111,16 +153,24 @@ var testBuildDefinition = build.BuildDefinition{
 		YamlFilename: converter.String("YamlFilename"),
 	},
 	Queue: &build.AgentPoolQueue{
+		Id:   converter.Int(testQueueID),
 		Name: converter.String("BuildPoolName"),
 		Pool: &build.TaskAgentPoolReference{
+			Id:   converter.Int(testPoolID),
 			Name: converter.String("BuildPoolName"),
 		},
 	},
 	QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
+	BadgeEnabled:   converter.Bool(false),
 	Type:           &build.DefinitionTypeValues.Build,
 	Quality:        &build.DefinitionQualityValues.Definition,
 	Triggers:       &[]interface{}{},
 	VariableGroups: &[]build.VariableGroup{},
+	Demands:        &[]interface{}{},
+
+	JobTimeoutInMinutes:       converter.Int(60),
+	JobCancelTimeoutInMinutes: converter.Int(5),
+	JobAuthorizationScope:     &build.BuildAuthorizationScopeValues.ProjectCollection,
 }
 
 // This definition matches the overall structure of what a configured Bitbucket git repository would
@@ -149,15 +199,23 @@ var testBuildDefinitionBitbucketWithCITrigger = build.BuildDefinition{
 		YamlFilename: converter.String("YamlFilename"),
 	},
 	Queue: &build.AgentPoolQueue{
+		Id:   converter.Int(testQueueID),
 		Name: converter.String("BuildPoolName"),
 		Pool: &build.TaskAgentPoolReference{
+			Id:   converter.Int(testPoolID),
 			Name: converter.String("BuildPoolName"),
 		},
 	},
 	QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
+	BadgeEnabled:   converter.Bool(false),
 	Type:           &build.DefinitionTypeValues.Build,
 	Quality:        &build.DefinitionQualityValues.Definition,
 	VariableGroups: &[]build.VariableGroup{},
+	Demands:        &[]interface{}{},
+
+	JobTimeoutInMinutes:       converter.Int(60),
+	JobCancelTimeoutInMinutes: converter.Int(5),
+	JobAuthorizationScope:     &build.BuildAuthorizationScopeValues.ProjectCollection,
 }
 
 // This definition matches the overall structure of what a configured GitHub Enterprise git repository would
@@ -187,15 +245,23 @@ var testBuildDefinitionGitHubEnterpriseWithCITrigger = build.BuildDefinition{
 		YamlFilename: converter.String("YamlFilename"),
 	},
 	Queue: &build.AgentPoolQueue{
+		Id:   converter.Int(testQueueID),
 		Name: converter.String("BuildPoolName"),
 		Pool: &build.TaskAgentPoolReference{
+			Id:   converter.Int(testPoolID),
 			Name: converter.String("BuildPoolName"),
 		},
 	},
 	QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
+	BadgeEnabled:   converter.Bool(false),
 	Type:           &build.DefinitionTypeValues.Build,
 	Quality:        &build.DefinitionQualityValues.Definition,
 	VariableGroups: &[]build.VariableGroup{},
+	Demands:        &[]interface{}{},
+
+	JobTimeoutInMinutes:       converter.Int(60),
+	JobCancelTimeoutInMinutes: converter.Int(5),
+	JobAuthorizationScope:     &build.BuildAuthorizationScopeValues.ProjectCollection,
 }
 
 // This definition matches the overall structure of what a configured Bitbucket git repository would
@@ -221,15 +287,23 @@ func testBuildDefinitionBitbucket() build.BuildDefinition {
 			YamlFilename: converter.String("YamlFilename"),
 		},
 		Queue: &build.AgentPoolQueue{
+			Id:   converter.Int(testQueueID),
 			Name: converter.String("BuildPoolName"),
 			Pool: &build.TaskAgentPoolReference{
+				Id:   converter.Int(testPoolID),
 				Name: converter.String("BuildPoolName"),
 			},
 		},
 		QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
+		BadgeEnabled:   converter.Bool(false),
 		Type:           &build.DefinitionTypeValues.Build,
 		Quality:        &build.DefinitionQualityValues.Definition,
 		VariableGroups: &[]build.VariableGroup{},
+		Demands:        &[]interface{}{},
+
+		JobTimeoutInMinutes:       converter.Int(60),
+		JobCancelTimeoutInMinutes: converter.Int(5),
+		JobAuthorizationScope:     &build.BuildAuthorizationScopeValues.ProjectCollection,
 	}
 }
 
@@ -257,15 +331,23 @@ func testBuildDefinitionGitHubEnterprise() build.BuildDefinition {
 			YamlFilename: converter.String("YamlFilename"),
 		},
 		Queue: &build.AgentPoolQueue{
+			Id:   converter.Int(testQueueID),
 			Name: converter.String("BuildPoolName"),
 			Pool: &build.TaskAgentPoolReference{
+				Id:   converter.Int(testPoolID),
 				Name: converter.String("BuildPoolName"),
 			},
 		},
 		QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
+		BadgeEnabled:   converter.Bool(false),
 		Type:           &build.DefinitionTypeValues.Build,
 		Quality:        &build.DefinitionQualityValues.Definition,
 		VariableGroups: &[]build.VariableGroup{},
+		Demands:        &[]interface{}{},
+
+		JobTimeoutInMinutes:       converter.Int(60),
+		JobCancelTimeoutInMinutes: converter.Int(5),
+		JobAuthorizationScope:     &build.BuildAuthorizationScopeValues.ProjectCollection,
 	}
 }
 
@@ -386,7 +468,7 @@ func TestAzureDevOpsBuildDefinition_CITriggers_Bitbucket(t *testing.T) {
 	flattenBuildDefinition(resourceData, &testBuildDefinitionBitbucketWithCITrigger, testProjectID)
 
 	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
-	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+	clients := &client.AggregatedClient{BuildClient: buildClient, TaskAgentClient: mockTestQueue(ctrl), Ctx: context.Background()}
 
 	expectedArgs := build.CreateDefinitionArgs{Definition: &testBuildDefinitionBitbucketWithCITrigger, Project: &testProjectID}
 
@@ -408,7 +490,7 @@ func TestAzureDevOpsBuildDefinition_CITriggers_GitHubEnterprise(t *testing.T) {
 	flattenBuildDefinition(resourceData, &testBuildDefinitionGitHubEnterpriseWithCITrigger, testProjectID)
 
 	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
-	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+	clients := &client.AggregatedClient{BuildClient: buildClient, TaskAgentClient: mockTestQueue(ctrl), Ctx: context.Background()}
 
 	expectedArgs := build.CreateDefinitionArgs{Definition: &testBuildDefinitionGitHubEnterpriseWithCITrigger, Project: &testProjectID}
 
@@ -436,6 +518,140 @@ func TestBuildDefinition_ExpandFlatten_Roundtrip(t *testing.T) {
 	}
 }
 
+// verifies that the settings of a build definition made outside of the triggers and the process survive a round trip
+func TestBuildDefinition_ExpandFlatten_SettingsRoundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
+	testBuildDefinitionWithSettings := testBuildDefinition
+	testBuildDefinitionWithSettings.BuildNumberFormat = converter.String("$(Date:yyyyMMdd)$(Rev:.r)")
+	testBuildDefinitionWithSettings.QueueStatus = &build.DefinitionQueueStatusValues.Paused
+	testBuildDefinitionWithSettings.BadgeEnabled = converter.Bool(true)
+	testBuildDefinitionWithSettings.JobAuthorizationScope = &build.BuildAuthorizationScopeValues.Project
+	testBuildDefinitionWithSettings.RetentionRules = &[]build.RetentionPolicy{
+		{
+			Branches:              &[]string{"+refs/heads/*"},
+			DaysToKeep:            converter.Int(30),
+			MinimumToKeep:         converter.Int(5),
+			DeleteBuildRecord:     converter.Bool(true),
+			DeleteTestResults:     converter.Bool(false),
+			Artifacts:             &[]string{"build.SourceLabel"},
+			ArtifactTypesToDelete: &[]string{"FilePath", "SymbolStore"},
+		},
+	}
+
+	flattenBuildDefinition(resourceData, &testBuildDefinitionWithSettings, testProjectID)
+	buildDefinitionAfterRoundTrip, _, err := expandBuildDefinition(resourceData)
+
+	require.Nil(t, err)
+	require.Equal(t, testBuildDefinitionWithSettings, *buildDefinitionAfterRoundTrip)
+}
+
+// verifies that the job settings are only sent to the service if they are configured
+func TestBuildDefinition_Expand_SendsConfiguredJobSettings(t *testing.T) {
+	config := map[string]interface{}{
+		"project_id": testProjectID,
+		"repository": []interface{}{
+			map[string]interface{}{
+				"repo_id":   "RepoId",
+				"repo_type": "TfsGit",
+				"yml_path":  "azure-pipelines.yml",
+			},
+		},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, config)
+	buildDefinition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+	require.Nil(t, buildDefinition.JobTimeoutInMinutes)
+	require.Nil(t, buildDefinition.JobCancelTimeoutInMinutes)
+	require.Nil(t, buildDefinition.JobAuthorizationScope)
+
+	config["job_timeout_in_minutes"] = 0
+	config["job_cancel_timeout_in_minutes"] = 10
+	config["job_authorization_scope"] = "project"
+	resourceData = schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, config)
+	buildDefinition, _, err = expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, 0, *buildDefinition.JobTimeoutInMinutes)
+	require.Equal(t, 10, *buildDefinition.JobCancelTimeoutInMinutes)
+	require.Equal(t, build.BuildAuthorizationScopeValues.Project, *buildDefinition.JobAuthorizationScope)
+}
+
+// verifies that the phases and tasks of a designer process survive a round trip through the service
+func TestBuildDefinition_ExpandFlatten_DesignerProcessRoundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, map[string]interface{}{
+		"project_id": testProjectID,
+		"repository": []interface{}{
+			map[string]interface{}{
+				"repo_id":   "RepoId",
+				"repo_type": "TfsGit",
+			},
+		},
+		"vm_image": "ubuntu-latest",
+		"phase": []interface{}{
+			map[string]interface{}{
+				"name": "Agent job 1",
+				"task": []interface{}{
+					map[string]interface{}{
+						"task_id":      "d9bafed4-0b18-4f58-968d-86655b4d2ce9",
+						"version":      "2.*",
+						"display_name": "Run a script",
+						"inputs": map[string]interface{}{
+							"script": "echo hello",
+						},
+					},
+					map[string]interface{}{
+						"task_id":           "2ff763a7-ce83-4e1f-bc89-0ae63477cebe",
+						"version":           "1.*",
+						"display_name":      "Publish",
+						"condition":         "always()",
+						"continue_on_error": true,
+					},
+				},
+			},
+		},
+	})
+	expandedDefinition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+
+	// the service returns the process as JSON object
+	var expandedProcess map[string]interface{}
+	processJSON, err := json.Marshal(expandedDefinition.Process)
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(processJSON, &expandedProcess))
+	require.Equal(t, float64(1), expandedProcess["type"])
+	expandedDefinition.Process = expandedProcess
+	expandedDefinition.Id = converter.Int(100)
+
+	resourceDataAfterRead := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceDataAfterRead, expandedDefinition, testProjectID)
+	require.Equal(t, "Run a script", resourceDataAfterRead.Get("phase.0.task.0.display_name"))
+	require.Equal(t, "echo hello", resourceDataAfterRead.Get("phase.0.task.0.inputs.script"))
+	require.Equal(t, "always()", resourceDataAfterRead.Get("phase.0.task.1.condition"))
+	require.Equal(t, "ubuntu-latest", resourceDataAfterRead.Get("vm_image"))
+
+	definitionAfterRoundTrip, _, err := expandBuildDefinition(resourceDataAfterRead)
+	require.Nil(t, err)
+	processAfterRoundTripJSON, err := json.Marshal(definitionAfterRoundTrip.Process)
+	require.Nil(t, err)
+	require.JSONEq(t, string(processJSON), string(processAfterRoundTripJSON))
+
+	// the service returns all inputs of the tasks, only the configured inputs are kept
+	steps := expandedProcess["phases"].([]interface{})[0].(map[string]interface{})["steps"].([]interface{})
+	steps[0].(map[string]interface{})["inputs"] = map[string]interface{}{"script": "echo hello", "workingDirectory": ""}
+	steps[1].(map[string]interface{})["inputs"] = map[string]interface{}{"artifactName": "drop"}
+	flattenBuildDefinition(resourceData, expandedDefinition, testProjectID)
+	require.Equal(t, map[string]interface{}{"script": "echo hello"}, resourceData.Get("phase.0.task.0.inputs"))
+	require.Empty(t, resourceData.Get("phase.0.task.1.inputs"))
+}
+
+// verifies that the days of a schedule are read from both representations of the flags enum
+func TestBuildDefinition_FlattenScheduleDays(t *testing.T) {
+	require.Equal(t, []interface{}{"Mon", "Wed"}, flattenScheduleDays(float64(5)))
+	require.Equal(t, []interface{}{"Mon", "Fri", "Sun"}, flattenScheduleDays("monday, friday, sunday"))
+	require.Equal(t, []interface{}{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}, flattenScheduleDays("all"))
+	require.Equal(t, []interface{}{}, flattenScheduleDays("none"))
+}
+
 // verifies that an expand will fail if there is insufficient configuration data found in the resource
 func TestBuildDefinition_Expand_FailsIfNotEnoughData(t *testing.T) {
 	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
@@ -452,7 +668,7 @@ func TestBuildDefinition_Create_DoesNotSwallowError(t *testing.T) {
 	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
 
 	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
-	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+	clients := &client.AggregatedClient{BuildClient: buildClient, TaskAgentClient: mockTestQueue(ctrl), Ctx: context.Background()}
 
 	expectedArgs := build.CreateDefinitionArgs{Definition: &testBuildDefinition, Project: &testProjectID}
 	buildClient.
@@ -518,7 +734,7 @@ func TestBuildDefinition_Update_DoesNotSwallowError(t *testing.T) {
 	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
 
 	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
-	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+	clients := &client.AggregatedClient{BuildClient: buildClient, TaskAgentClient: mockTestQueue(ctrl), Ctx: context.Background()}
 
 	expectedArgs := build.UpdateDefinitionArgs{
 		Definition:   &testBuildDefinition,
@@ -548,6 +764,57 @@ func TestExpandVariables_CatchesDuplicateVariables(t *testing.T) {
 	require.Contains(t, err.Error(), "Unexpectedly found duplicate variable with name")
 }
 
+// mockTestQueue returns a task agent client resolving the agent queue referenced by the test build definitions
+func mockTestQueue(ctrl *gomock.Controller) *azdosdkmocks.MockTaskagentClient {
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(gomock.Any(), taskagent.GetAgentQueueArgs{Project: &testProjectID, QueueId: converter.Int(testQueueID)}).
+		Return(&taskagent.TaskAgentQueue{
+			Id:   converter.Int(testQueueID),
+			Name: converter.String("BuildPoolName"),
+			Pool: &taskagent.TaskAgentPoolReference{
+				Id:   converter.Int(testPoolID),
+				Name: converter.String("BuildPoolName"),
+			},
+		}, nil).
+		Times(1)
+	return taskAgentClient
+}
+
+// verifies that a queue configured by name must exist in the project
+func TestBuildDefinition_Create_ValidatesQueueExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
+	testBuildDefinitionWithoutQueueIDs := testBuildDefinition
+	testBuildDefinitionWithoutQueueIDs.Queue = &build.AgentPoolQueue{
+		Pool: &build.TaskAgentPoolReference{
+			Name: converter.String("MissingPool"),
+		},
+	}
+	flattenBuildDefinition(resourceData, &testBuildDefinitionWithoutQueueIDs, testProjectID)
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueuesByNames(clients.Ctx, taskagent.GetAgentQueuesByNamesArgs{Project: &testProjectID, QueueNames: &[]string{"MissingPool"}}).
+		Return(&[]taskagent.TaskAgentQueue{}, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		CreateDefinition(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := resourceBuildDefinitionCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Agent queue MissingPool does not exist in project")
+}
+
 func sortBuildDefinition(b build.BuildDefinition) build.BuildDefinition {
 	if b.Triggers == nil {
 		return b
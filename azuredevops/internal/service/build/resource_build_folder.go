@@ -0,0 +1,148 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/validate"
+)
+
+// ResourceBuildFolder schema and implementation for build folder resource
+func ResourceBuildFolder() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceBuildFolderCreate,
+		Read:     resourceBuildFolderRead,
+		Update:   resourceBuildFolderUpdate,
+		Delete:   resourceBuildFolderDelete,
+		Importer: tfhelper.ImportProjectQualifiedResource(),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.Path,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceBuildFolderCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	path := d.Get("path").(string)
+
+	createdFolder, err := clients.BuildClient.CreateFolder(clients.Ctx, build.CreateFolderArgs{
+		Project: converter.String(projectID),
+		Path:    converter.String(path),
+		Folder:  expandBuildFolder(d),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating build folder %s in project %s: %+v", path, projectID, err)
+	}
+
+	d.SetId(converter.ToString(createdFolder.Path, path))
+	return resourceBuildFolderRead(d, m)
+}
+
+func resourceBuildFolderRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	folder, err := getBuildFolder(clients, projectID, d.Id())
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading build folder %s in project %s: %+v", d.Id(), projectID, err)
+	}
+	if folder == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(converter.ToString(folder.Path, d.Id()))
+	d.Set("path", folder.Path)
+	d.Set("description", converter.ToString(folder.Description, ""))
+	return nil
+}
+
+func resourceBuildFolderUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	// The folder is addressed by its current path, a changed path moves the folder
+	// including all build definitions and subfolders.
+	updatedFolder, err := clients.BuildClient.UpdateFolder(clients.Ctx, build.UpdateFolderArgs{
+		Project: converter.String(projectID),
+		Path:    converter.String(d.Id()),
+		Folder:  expandBuildFolder(d),
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating build folder %s in project %s: %+v", d.Id(), projectID, err)
+	}
+
+	d.SetId(converter.ToString(updatedFolder.Path, d.Get("path").(string)))
+	return resourceBuildFolderRead(d, m)
+}
+
+func resourceBuildFolderDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	err := clients.BuildClient.DeleteFolder(clients.Ctx, build.DeleteFolderArgs{
+		Project: converter.String(projectID),
+		Path:    converter.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting build folder %s in project %s: %+v", d.Id(), projectID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandBuildFolder(d *schema.ResourceData) *build.Folder {
+	return &build.Folder{
+		Path:        converter.String(d.Get("path").(string)),
+		Description: converter.String(d.Get("description").(string)),
+	}
+}
+
+// getBuildFolder returns nil if the folder does not exist. The service returns the folder
+// along with all its subfolders, so the result is filtered by the path.
+func getBuildFolder(clients *client.AggregatedClient, projectID string, path string) (*build.Folder, error) {
+	folders, err := clients.BuildClient.GetFolders(clients.Ctx, build.GetFoldersArgs{
+		Project: converter.String(projectID),
+		Path:    converter.String(path),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if folders == nil {
+		return nil, nil
+	}
+
+	for _, folder := range *folders {
+		if strings.EqualFold(converter.ToString(folder.Path, ""), path) {
+			return &folder, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,142 @@
+// +build all resource_build_folder
+// +build !exclude_resource_build_folder
+
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+// verifies that if an error is produced on create, it is not swallowed
+func TestBuildFolder_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildFolder().Schema, nil)
+	resourceData.Set("project_id", testProjectID)
+	resourceData.Set("path", `\Team\Pipelines`)
+	resourceData.Set("description", "Pipelines of the team")
+
+	buildClient.
+		EXPECT().
+		CreateFolder(clients.Ctx, build.CreateFolderArgs{
+			Project: &testProjectID,
+			Path:    converter.String(`\Team\Pipelines`),
+			Folder: &build.Folder{
+				Path:        converter.String(`\Team\Pipelines`),
+				Description: converter.String("Pipelines of the team"),
+			},
+		}).
+		Return(nil, errors.New("CreateFolder() Failed")).
+		Times(1)
+
+	err := resourceBuildFolderCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "CreateFolder() Failed")
+}
+
+// verifies that the folder is read from the list of folders returned for its path, which contains its subfolders
+func TestBuildFolder_Read_FiltersSubfolders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildFolder().Schema, nil)
+	resourceData.SetId(`\Team`)
+	resourceData.Set("project_id", testProjectID)
+
+	buildClient.
+		EXPECT().
+		GetFolders(clients.Ctx, build.GetFoldersArgs{
+			Project: &testProjectID,
+			Path:    converter.String(`\Team`),
+		}).
+		Return(&[]build.Folder{
+			{Path: converter.String(`\Team\Pipelines`), Description: converter.String("Subfolder")},
+			{Path: converter.String(`\Team`), Description: converter.String("Folder")},
+		}, nil).
+		Times(1)
+
+	err := resourceBuildFolderRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, `\Team`, resourceData.Id())
+	require.Equal(t, `\Team`, resourceData.Get("path"))
+	require.Equal(t, "Folder", resourceData.Get("description"))
+}
+
+// verifies that a folder which no longer exists is removed from the state
+func TestBuildFolder_Read_RemovesMissingFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildFolder().Schema, nil)
+	resourceData.SetId(`\Team`)
+	resourceData.Set("project_id", testProjectID)
+
+	buildClient.
+		EXPECT().
+		GetFolders(clients.Ctx, gomock.Any()).
+		Return(&[]build.Folder{}, nil).
+		Times(1)
+
+	err := resourceBuildFolderRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that a folder is moved by addressing it with its current path
+func TestBuildFolder_Update_MovesFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildFolder().Schema, nil)
+	resourceData.SetId(`\Team`)
+	resourceData.Set("project_id", testProjectID)
+	resourceData.Set("path", `\Renamed`)
+
+	buildClient.
+		EXPECT().
+		UpdateFolder(clients.Ctx, build.UpdateFolderArgs{
+			Project: &testProjectID,
+			Path:    converter.String(`\Team`),
+			Folder: &build.Folder{
+				Path:        converter.String(`\Renamed`),
+				Description: converter.String(""),
+			},
+		}).
+		Return(&build.Folder{Path: converter.String(`\Renamed`)}, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetFolders(clients.Ctx, build.GetFoldersArgs{
+			Project: &testProjectID,
+			Path:    converter.String(`\Renamed`),
+		}).
+		Return(&[]build.Folder{{Path: converter.String(`\Renamed`)}}, nil).
+		Times(1)
+
+	err := resourceBuildFolderUpdate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, `\Renamed`, resourceData.Id())
+}
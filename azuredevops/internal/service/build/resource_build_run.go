@@ -0,0 +1,309 @@
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceBuildRun schema and implementation for build run resource. A build is queued when the
+// resource is created and whenever the project, the definition or the triggers change, all other
+// changes only update the state.
+func ResourceBuildRun() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceBuildRunCreate,
+		Read:     resourceBuildRunRead,
+		Update:   resourceBuildRunUpdate,
+		Delete:   resourceBuildRunDelete,
+		Importer: tfhelper.ImportProjectQualifiedResource(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"definition_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"branch": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"variables": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"build_number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"result": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBuildRunCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	definitionID := d.Get("definition_id").(int)
+
+	buildToQueue, err := expandBuildRun(d)
+	if err != nil {
+		return err
+	}
+
+	queuedBuild, err := queueBuildRun(clients, projectID, buildToQueue)
+	if err != nil {
+		return fmt.Errorf("Error queueing build for build definition %d in project %s: %+v", definitionID, projectID, err)
+	}
+
+	// The ID is set before waiting for the build, a failed build taints the resource so it is queued again
+	// with the next apply.
+	d.SetId(strconv.Itoa(*queuedBuild.Id))
+
+	err = waitForBuildRun(clients, projectID, *queuedBuild.Id, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+	return resourceBuildRunRead(d, m)
+}
+
+// The template parameters of a build are not part of the build model of the SDK, the build is therefore
+// queued with a raw request.
+var buildsLocationID = uuid.MustParse("0cd358e1-9217-4d94-8269-1c1ee6f93dcf")
+
+type buildRunRequest struct {
+	build.Build
+	TemplateParameters map[string]string `json:"templateParameters,omitempty"`
+}
+
+// using: POST https://dev.azure.com/{organization}/{project}/_apis/build/builds?api-version=6.0
+func queueBuildRun(clients *client.AggregatedClient, projectID string, buildToQueue *buildRunRequest) (*build.Build, error) {
+	var queuedBuild build.Build
+	err := utils.SendRestRequest(clients.Ctx, clients.BuildClient, &utils.RestRequest{
+		Method:     http.MethodPost,
+		LocationID: buildsLocationID,
+		APIVersion: "6.0",
+		RouteValues: map[string]string{
+			"project": projectID,
+		},
+		Body: buildToQueue,
+	}, &queuedBuild)
+	if err != nil {
+		return nil, err
+	}
+	return &queuedBuild, nil
+}
+
+func waitForBuildRun(clients *client.AggregatedClient, projectID string, buildID int, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		ContinuousTargetOccurence: 1,
+		Delay:                     5 * time.Second,
+		MinTimeout:                10 * time.Second,
+		Pending: []string{
+			string(build.BuildStatusValues.None),
+			string(build.BuildStatusValues.NotStarted),
+			string(build.BuildStatusValues.Postponed),
+			string(build.BuildStatusValues.InProgress),
+			string(build.BuildStatusValues.Cancelling),
+		},
+		Target: []string{
+			string(build.BuildStatusValues.Completed),
+		},
+		Refresh: buildRunStatusRefreshFunc(clients, projectID, buildID),
+		Timeout: timeout,
+	}
+
+	completedBuild, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for build %d in project %s to complete: %+v", buildID, projectID, err)
+	}
+	return checkBuildRunResult(completedBuild.(*build.Build))
+}
+
+func buildRunStatusRefreshFunc(clients *client.AggregatedClient, projectID string, buildID int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ret, err := clients.BuildClient.GetBuild(clients.Ctx, build.GetBuildArgs{
+			Project: converter.String(projectID),
+			BuildId: converter.Int(buildID),
+		})
+		if err != nil {
+			return nil, string(build.BuildStatusValues.None), err
+		}
+
+		status := build.BuildStatusValues.None
+		if ret.Status != nil {
+			status = *ret.Status
+		}
+		if status != build.BuildStatusValues.Completed {
+			log.Printf("[DEBUG] Waiting for build %d to complete. Build status %s", buildID, status)
+		}
+
+		return ret, string(status), nil
+	}
+}
+
+// checkBuildRunResult returns an error for every completed build which did not succeed
+func checkBuildRunResult(completedBuild *build.Build) error {
+	result := build.BuildResultValues.None
+	if completedBuild.Result != nil {
+		result = *completedBuild.Result
+	}
+	if result == build.BuildResultValues.Succeeded {
+		return nil
+	}
+	return fmt.Errorf("Build %d (%s) completed with result %s",
+		*completedBuild.Id, converter.ToString(completedBuild.BuildNumber, ""), result)
+}
+
+func resourceBuildRunRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	buildID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing the build ID from the Terraform resource data: %v", err)
+	}
+
+	ret, err := clients.BuildClient.GetBuild(clients.Ctx, build.GetBuildArgs{
+		Project: converter.String(projectID),
+		BuildId: converter.Int(buildID),
+	})
+	if err != nil {
+		// Builds are removed by the retention policies of the project, the run is kept in the
+		// state because removing it would queue a new build with the next apply.
+		if utils.ResponseWasNotFound(err) {
+			log.Printf("[WARN] Build %d does not exist anymore in project %s", buildID, projectID)
+			return nil
+		}
+		return fmt.Errorf("Error reading build %d in project %s: %+v", buildID, projectID, err)
+	}
+
+	flattenBuildRun(d, ret)
+	return nil
+}
+
+// resourceBuildRunUpdate does not queue a build, a new build is only queued if the project, the
+// definition or the triggers change
+func resourceBuildRunUpdate(d *schema.ResourceData, m interface{}) error {
+	return resourceBuildRunRead(d, m)
+}
+
+// resourceBuildRunDelete only removes the build run from the state, builds cannot be undone
+func resourceBuildRunDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func expandBuildRun(d *schema.ResourceData) (*buildRunRequest, error) {
+	buildToQueue := &buildRunRequest{
+		Build: build.Build{
+			Definition: &build.DefinitionReference{
+				Id: converter.Int(d.Get("definition_id").(int)),
+			},
+		},
+	}
+
+	if branch := d.Get("branch").(string); branch != "" {
+		buildToQueue.SourceBranch = converter.String(branch)
+	}
+
+	if parameters := expandBuildRunValues(d.Get("parameters").(map[string]interface{})); len(parameters) > 0 {
+		buildToQueue.TemplateParameters = parameters
+	}
+
+	// The service receives the values for the queue time variables as a serialized dictionary
+	if variables := expandBuildRunValues(d.Get("variables").(map[string]interface{})); len(variables) > 0 {
+		values, err := json.Marshal(variables)
+		if err != nil {
+			return nil, fmt.Errorf("Error serializing the variables of the build: %v", err)
+		}
+		buildToQueue.Parameters = converter.String(string(values))
+	}
+
+	return buildToQueue, nil
+}
+
+func expandBuildRunValues(values map[string]interface{}) map[string]string {
+	ret := map[string]string{}
+	for name, value := range values {
+		ret[name] = value.(string)
+	}
+	return ret
+}
+
+func flattenBuildRun(d *schema.ResourceData, ret *build.Build) {
+	d.SetId(strconv.Itoa(*ret.Id))
+	if ret.Definition != nil && ret.Definition.Id != nil {
+		d.Set("definition_id", *ret.Definition.Id)
+	}
+	// The branch is only read if it is not configured, otherwise a changed configuration would never
+	// converge without queueing a new build.
+	if _, ok := d.GetOk("branch"); !ok {
+		d.Set("branch", converter.ToString(ret.SourceBranch, ""))
+	}
+	d.Set("build_number", converter.ToString(ret.BuildNumber, ""))
+	d.Set("source_version", converter.ToString(ret.SourceVersion, ""))
+	d.Set("url", converter.ToString(ret.Url, ""))
+	if ret.Status != nil {
+		d.Set("status", string(*ret.Status))
+	}
+	if ret.Result != nil {
+		d.Set("result", string(*ret.Result))
+	}
+}
@@ -0,0 +1,158 @@
+// +build all resource_build_run
+// +build !exclude_resource_build_run
+
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBuildRunProjectID = uuid.New().String()
+
+func getTestBuildRunClients(t *testing.T, handler http.HandlerFunc) (*client.AggregatedClient, *testhelper.RestServer) {
+	server := testhelper.NewRestServer(t, map[uuid.UUID]string{
+		buildsLocationID: "{project}/_apis/build/builds/{buildId}",
+	}, handler)
+	clients := &client.AggregatedClient{
+		BuildClient: &build.ClientImpl{Client: *server.Client},
+		Ctx:         context.Background(),
+	}
+	return clients, server
+}
+
+// verifies that the branch, the template parameters and the variables are sent to the service when the build is queued
+func TestBuildRun_Create_QueuesBuildWithParametersAndVariables(t *testing.T) {
+	clients, server := getTestBuildRunClients(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/"+testBuildRunProjectID+"/_apis/build/builds", r.URL.Path)
+
+		var request struct {
+			Definition         build.DefinitionReference `json:"definition"`
+			SourceBranch       string                    `json:"sourceBranch"`
+			Parameters         string                    `json:"parameters"`
+			TemplateParameters map[string]string         `json:"templateParameters"`
+		}
+		testhelper.ReadRestRequestBody(t, r, &request)
+		if assert.NotNil(t, request.Definition.Id) {
+			assert.Equal(t, 42, *request.Definition.Id)
+		}
+		assert.Equal(t, "refs/heads/bootstrap", request.SourceBranch)
+		assert.Equal(t, map[string]string{"environment": "dev", "region": "westeurope"}, request.TemplateParameters)
+		assert.JSONEq(t, `{"region": "northeurope"}`, request.Parameters)
+
+		testhelper.WriteRestError(t, w, http.StatusInternalServerError, "QueueBuild() Failed")
+	})
+	defer server.Close()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildRun().Schema, nil)
+	resourceData.Set("project_id", testBuildRunProjectID)
+	resourceData.Set("definition_id", 42)
+	resourceData.Set("branch", "refs/heads/bootstrap")
+	resourceData.Set("parameters", map[string]interface{}{"environment": "dev", "region": "westeurope"})
+	resourceData.Set("variables", map[string]interface{}{"region": "northeurope"})
+
+	err := resourceBuildRunCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "QueueBuild() Failed")
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that neither template parameters nor variables are sent to the service if none are configured
+func TestBuildRun_ExpandBuildRun_OmitsUnconfiguredValues(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildRun().Schema, nil)
+	resourceData.Set("project_id", testBuildRunProjectID)
+	resourceData.Set("definition_id", 42)
+
+	buildToQueue, err := expandBuildRun(resourceData)
+	require.Nil(t, err)
+
+	body, err := json.Marshal(buildToQueue)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"definition": {"id": 42}}`, string(body))
+}
+
+// verifies that the refresh function reports the status of the build
+func TestBuildRun_StatusRefreshFunc_ReportsBuildStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	inProgress := build.BuildStatusValues.InProgress
+	buildClient.
+		EXPECT().
+		GetBuild(clients.Ctx, build.GetBuildArgs{
+			Project: &testBuildRunProjectID,
+			BuildId: converter.Int(7),
+		}).
+		Return(&build.Build{Id: converter.Int(7), Status: &inProgress}, nil).
+		Times(1)
+
+	_, status, err := buildRunStatusRefreshFunc(clients, testBuildRunProjectID, 7)()
+	require.Nil(t, err)
+	require.Equal(t, string(build.BuildStatusValues.InProgress), status)
+}
+
+// verifies that only succeeded builds are accepted
+func TestBuildRun_CheckResult_FailsOnUnsuccessfulResults(t *testing.T) {
+	results := map[build.BuildResult]bool{
+		build.BuildResultValues.Succeeded:          true,
+		build.BuildResultValues.PartiallySucceeded: false,
+		build.BuildResultValues.Failed:             false,
+		build.BuildResultValues.Canceled:           false,
+	}
+
+	for result, succeeded := range results {
+		result := result
+		err := checkBuildRunResult(&build.Build{
+			Id:          converter.Int(7),
+			BuildNumber: converter.String("20201019.1"),
+			Result:      &result,
+		})
+		if succeeded {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), string(result))
+		}
+	}
+}
+
+// verifies that a build removed by the retention policies does not remove the run from the state
+func TestBuildRun_Read_KeepsRemovedBuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildRun().Schema, nil)
+	resourceData.SetId("7")
+	resourceData.Set("project_id", testBuildRunProjectID)
+
+	buildClient.
+		EXPECT().
+		GetBuild(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(404)}).
+		Times(1)
+
+	err := resourceBuildRunRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "7", resourceData.Id())
+}
@@ -1,17 +1,17 @@
 package build
 
 import (
-	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 )
 
 const msgErrorFailedResourceCreate = "error creating authorized resource: %+v"
@@ -19,13 +19,27 @@ const msgErrorFailedResourceUpdate = "error updating authorized resource: %+v"
 const msgErrorFailedResourceDelete = "error deleting authorized resource: %+v"
 const msgErrorAuthorizationNoLongerExists = "[WARN] The authorization with ID '%s' no longer exists. Setting Id to empty \n"
 
-// ResourceResourceAuthorization schema and implementation for resource authorization resource
+// The types of resources which can be authorized for pipelines
+var authorizedResourceTypes = []string{
+	"endpoint",
+	"queue",
+	"variablegroup",
+	"securefile",
+	"environment",
+	"repository",
+}
+
+// ResourceResourceAuthorization schema and implementation for resource authorization resource. A resource is
+// either authorized for a single pipeline or, if no definition is configured, for all pipelines of the project.
 func ResourceResourceAuthorization() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceResourceAuthorizationCreate,
 		Read:   resourceResourceAuthorizationRead,
 		Update: resourceResourceAuthorizationUpdate,
 		Delete: resourceResourceAuthorizationDelete,
+		Importer: &schema.ResourceImporter{
+			State: importResourceAuthorization,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
@@ -36,22 +50,25 @@ func ResourceResourceAuthorization() *schema.Resource {
 			"resource_id": {
 				Type:         schema.TypeString,
 				Required:     true,
+				ForceNew:     true,
 				Description:  "id of the resource",
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 			"definition_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
+				ForceNew:     true,
 				Description:  "id of the build definition",
 				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"type": {
 				Type:             schema.TypeString,
 				Optional:         true,
+				ForceNew:         true,
 				Default:          "endpoint",
 				Description:      "type of the resource",
 				DiffSuppressFunc: suppress.CaseDifference,
-				ValidateFunc:     validation.StringInSlice([]string{"endpoint", "queue", "variablegroup"}, false),
+				ValidateFunc:     validation.StringInSlice(authorizedResourceTypes, false),
 			},
 			"authorized": {
 				Type:        schema.TypeBool,
@@ -64,102 +81,58 @@ func ResourceResourceAuthorization() *schema.Resource {
 
 func resourceResourceAuthorizationCreate(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*client.AggregatedClient)
-	authorizedResource, projectID, definitionID := expandAuthorizedResource(d)
+	authorization := expandAuthorizedResource(d)
 
-	err := sendAuthorizedResourceToAPI(clients, authorizedResource, projectID, definitionID)
+	err := sendAuthorizedResourceToAPI(clients, authorization)
 	if err != nil {
 		return fmt.Errorf(msgErrorFailedResourceCreate, err)
 	}
 
+	d.SetId(authorization.ResourceID)
 	return resourceResourceAuthorizationRead(d, m)
 }
 
 func resourceResourceAuthorizationRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
 	clients := m.(*client.AggregatedClient)
+	authorization := expandAuthorizedResource(d)
 
-	authorizedResource, projectID, definitionID := expandAuthorizedResource(d)
-
-	if definitionID == 0 {
-		if *authorizedResource.Authorized {
-			// (attempt) flatten read result from ado
-			resourceRefs, err := clients.BuildClient.GetProjectResources(ctx, build.GetProjectResourcesArgs{
-				Project: &projectID,
-				Type:    authorizedResource.Type,
-				Id:      authorizedResource.Id,
-			})
-
-			if err != nil {
-				return err
-			}
-
-			if len(*resourceRefs) == 0 {
-				log.Printf(msgErrorAuthorizationNoLongerExists, *(authorizedResource.Id))
-				d.SetId("")
-				return nil
-			}
-
-			flattenAuthorizedResource(d, &(*resourceRefs)[0], projectID, definitionID)
-		} else {
-			// flatten structure provided by user-configuration and not read from ado
-			flattenAuthorizedResource(d, authorizedResource, projectID, definitionID)
-		}
-	} else {
-		resourceRefs, err := clients.BuildClient.GetDefinitionResources(ctx, build.GetDefinitionResourcesArgs{
-			Project:      &projectID,
-			DefinitionId: &definitionID,
-		})
-
-		if err != nil {
-			if utils.ResponseWasNotFound(err) {
-				log.Printf(msgErrorAuthorizationNoLongerExists, *(authorizedResource.Id))
-				d.SetId("")
-				return nil
-			}
-			return err
-		}
-
-		if len(*resourceRefs) == 0 {
-			log.Printf(msgErrorAuthorizationNoLongerExists, *(authorizedResource.Id))
+	permissions, err := getPipelinePermissions(clients, authorization)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			log.Printf(msgErrorAuthorizationNoLongerExists, authorization.ResourceID)
 			d.SetId("")
 			return nil
 		}
-
-		for _, resource := range *resourceRefs {
-			if resource.Id == authorizedResource.Id {
-				flattenAuthorizedResource(d, &resource, projectID, definitionID)
-				return nil
-			}
-		}
-
-		// flatten structure provided by user-configuration and not read from ado
-		flattenAuthorizedResource(d, authorizedResource, projectID, definitionID)
+		return err
 	}
 
+	authorization.Authorized = isPipelineAuthorized(permissions, authorization.DefinitionID)
+	flattenAuthorizedResource(d, authorization)
 	return nil
 }
 
 func resourceResourceAuthorizationDelete(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*client.AggregatedClient)
-	authorizedResource, projectID, definitionID := expandAuthorizedResource(d)
+	authorization := expandAuthorizedResource(d)
 
 	// deletion works only by setting authorized to false
 	// because the resource to delete might have had this parameter set to true, we overwrite it
-	authorizedResource.Authorized = converter.Bool(false)
+	authorization.Authorized = false
 
-	err := sendAuthorizedResourceToAPI(clients, authorizedResource, projectID, definitionID)
+	err := sendAuthorizedResourceToAPI(clients, authorization)
 	if err != nil {
 		return fmt.Errorf(msgErrorFailedResourceDelete, err)
 	}
 
+	d.SetId("")
 	return nil
 }
 
 func resourceResourceAuthorizationUpdate(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*client.AggregatedClient)
-	authorizedResource, projectID, definitionID := expandAuthorizedResource(d)
+	authorization := expandAuthorizedResource(d)
 
-	err := sendAuthorizedResourceToAPI(clients, authorizedResource, projectID, definitionID)
+	err := sendAuthorizedResourceToAPI(clients, authorization)
 	if err != nil {
 		return fmt.Errorf(msgErrorFailedResourceUpdate, err)
 	}
@@ -167,41 +140,139 @@ func resourceResourceAuthorizationUpdate(d *schema.ResourceData, m interface{})
 	return resourceResourceAuthorizationRead(d, m)
 }
 
-func flattenAuthorizedResource(d *schema.ResourceData, authorizedResource *build.DefinitionResourceReference, projectID string, definitionID int) {
-	d.SetId(*authorizedResource.Id)
-	d.Set("resource_id", authorizedResource.Id)
-	d.Set("type", authorizedResource.Type)
-	d.Set("authorized", authorizedResource.Authorized)
+// importResourceAuthorization imports the authorization of a resource for all pipelines of a project
+// by an ID of the form <project name or ID>/<type>/<resource ID>
+func importResourceAuthorization(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected project/type/resource_id", d.Id())
+	}
+
+	resourceType := strings.ToLower(parts[1])
+	if !isAuthorizedResourceType(resourceType) {
+		return nil, fmt.Errorf("unsupported resource type %s, expected one of %s", parts[1], strings.Join(authorizedResourceTypes, ", "))
+	}
+
+	projectID, err := tfhelper.GetRealProjectId(parts[0], m)
+	if err != nil {
+		return nil, err
+	}
+
 	d.Set("project_id", projectID)
-	d.Set("definition_id", definitionID)
+	d.Set("type", resourceType)
+	d.Set("resource_id", parts[2])
+	d.SetId(parts[2])
+	return []*schema.ResourceData{d}, nil
 }
 
-func expandAuthorizedResource(d *schema.ResourceData) (*build.DefinitionResourceReference, string, int) {
-	resourceRef := build.DefinitionResourceReference{
-		Authorized: converter.Bool(d.Get("authorized").(bool)),
-		Id:         converter.String(d.Get("resource_id").(string)),
-		Name:       nil,
-		Type:       converter.String(d.Get("type").(string)),
+func isAuthorizedResourceType(resourceType string) bool {
+	for _, authorizedResourceType := range authorizedResourceTypes {
+		if authorizedResourceType == resourceType {
+			return true
+		}
 	}
+	return false
+}
 
-	return &resourceRef, d.Get("project_id").(string), d.Get("definition_id").(int)
+// authorizedResource is the configuration of the authorization of a resource. A DefinitionID of
+// 0 refers to all pipelines of the project.
+type authorizedResource struct {
+	ProjectID    string
+	Type         string
+	ResourceID   string
+	DefinitionID int
+	Authorized   bool
 }
 
-func sendAuthorizedResourceToAPI(clients *client.AggregatedClient, resourceRef *build.DefinitionResourceReference, projectID string, definitionID int) error {
-	ctx := context.Background()
-	var err error
-	if definitionID == 0 {
-		_, err = clients.BuildClient.AuthorizeProjectResources(ctx, build.AuthorizeProjectResourcesArgs{
-			Resources: &[]build.DefinitionResourceReference{*resourceRef},
-			Project:   &projectID,
-		})
+func flattenAuthorizedResource(d *schema.ResourceData, authorization *authorizedResource) {
+	d.SetId(authorization.ResourceID)
+	d.Set("resource_id", authorization.ResourceID)
+	d.Set("type", authorization.Type)
+	d.Set("authorized", authorization.Authorized)
+	d.Set("project_id", authorization.ProjectID)
+	d.Set("definition_id", authorization.DefinitionID)
+}
+
+func expandAuthorizedResource(d *schema.ResourceData) *authorizedResource {
+	return &authorizedResource{
+		ProjectID:    d.Get("project_id").(string),
+		Type:         strings.ToLower(d.Get("type").(string)),
+		ResourceID:   d.Get("resource_id").(string),
+		DefinitionID: d.Get("definition_id").(int),
+		Authorized:   d.Get("authorized").(bool),
+	}
+}
+
+// expandPipelinePermissions returns the permissions to send to the service, either for a single pipeline or for all pipelines
+func expandPipelinePermissions(authorization *authorizedResource) *pipelinePermissions {
+	permissions := &pipelinePermissions{}
+	if authorization.DefinitionID == 0 {
+		permissions.AllPipelines = &pipelinePermission{
+			Authorized: converter.Bool(authorization.Authorized),
+		}
 	} else {
-		_, err = clients.BuildClient.AuthorizeDefinitionResources(ctx, build.AuthorizeDefinitionResourcesArgs{
-			Resources:    &[]build.DefinitionResourceReference{*resourceRef},
-			Project:      &projectID,
-			DefinitionId: &definitionID,
-		})
+		permissions.Pipelines = &[]pipelinePermission{
+			{
+				Id:         converter.Int(authorization.DefinitionID),
+				Authorized: converter.Bool(authorization.Authorized),
+			},
+		}
+	}
+	return permissions
+}
+
+// isPipelineAuthorized returns true if the resource is authorized for the pipeline, or for all pipelines if the definitionID is 0
+func isPipelineAuthorized(permissions *pipelinePermissions, definitionID int) bool {
+	if permissions == nil {
+		return false
 	}
+	if definitionID == 0 {
+		return permissions.AllPipelines != nil && converter.ToBool(permissions.AllPipelines.Authorized, false)
+	}
+	if permissions.Pipelines == nil {
+		return false
+	}
+	for _, pipeline := range *permissions.Pipelines {
+		if pipeline.Id != nil && *pipeline.Id == definitionID {
+			return converter.ToBool(pipeline.Authorized, false)
+		}
+	}
+	return false
+}
 
+// pipelinePermissionsResourceID returns the ID of the resource the pipeline permissions are addressed with.
+// Repositories are identified by the ID of their project and their own ID.
+func pipelinePermissionsResourceID(clients *client.AggregatedClient, authorization *authorizedResource) (string, error) {
+	if authorization.Type != "repository" || strings.Contains(authorization.ResourceID, ".") {
+		return authorization.ResourceID, nil
+	}
+
+	projectID, err := tfhelper.GetRealProjectId(authorization.ProjectID, clients)
+	if err != nil {
+		return "", err
+	}
+	return projectID + "." + authorization.ResourceID, nil
+}
+
+func getPipelinePermissions(clients *client.AggregatedClient, authorization *authorizedResource) (*pipelinePermissions, error) {
+	resourceID, err := pipelinePermissionsResourceID(clients, authorization)
+	if err != nil {
+		return nil, err
+	}
+	return getPipelinePermissionsForResource(clients, authorization.ProjectID, authorization.Type, resourceID)
+}
+
+func sendAuthorizedResourceToAPI(clients *client.AggregatedClient, authorization *authorizedResource) error {
+	resourceID, err := pipelinePermissionsResourceID(clients, authorization)
+	if err != nil {
+		return err
+	}
+
+	permissions := expandPipelinePermissions(authorization)
+	permissions.Resource = &pipelinePermissionsResource{
+		Type: converter.String(authorization.Type),
+		Id:   converter.String(resourceID),
+	}
+	_, err = updatePipelinePermissionsForResource(clients, authorization.ProjectID, authorization.Type, resourceID, permissions)
 	return err
 }
@@ -4,11 +4,12 @@
 package build
 
 // The tests in this file use the mock clients in mock_client.go to mock out
-// the Azure DevOps client operations.
+// the Azure DevOps client operations. The pipeline permissions are not part of
+// the SDK and are sent to a fake service instead.
 
 import (
 	"context"
-	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
@@ -17,8 +18,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/testhelper"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,132 +30,197 @@ var projectID = "projectid"
 var definitionID = 666
 var endpointId = uuid.New()
 
-var resourceReferenceAuthorized = build.DefinitionResourceReference{
-	Authorized: converter.Bool(true),
-	Id:         converter.String(endpointId.String()),
-	Name:       nil,
-	Type:       converter.String("endpoint"),
+var resourceAuthorized = authorizedResource{
+	ProjectID:    projectID,
+	Type:         "endpoint",
+	ResourceID:   endpointId.String(),
+	DefinitionID: definitionID,
+	Authorized:   true,
 }
 
-var resourceReferenceNotAuthorized = build.DefinitionResourceReference{
-	Authorized: converter.Bool(false),
-	Id:         converter.String(endpointId.String()),
-	Name:       nil,
-	Type:       converter.String("endpoint"),
+func TestResourceAuthorization_FlattenExpand_RoundTrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceResourceAuthorization().Schema, nil)
+	flattenAuthorizedResource(resourceData, &resourceAuthorized)
+
+	resourceAfterRoundtrip := expandAuthorizedResource(resourceData)
+	require.Equal(t, resourceAuthorized, *resourceAfterRoundtrip)
+	require.Equal(t, endpointId.String(), resourceData.Id())
 }
 
-var projectResourcesArgsAuthorized = build.AuthorizeProjectResourcesArgs{
-	Resources: &[]build.DefinitionResourceReference{resourceReferenceAuthorized},
-	Project:   &projectID,
+// verifies that a definition scopes the permissions to a single pipeline, otherwise all pipelines are authorized
+func TestResourceAuthorization_ExpandPipelinePermissions(t *testing.T) {
+	permissions := expandPipelinePermissions(&resourceAuthorized)
+	require.Nil(t, permissions.AllPipelines)
+	require.Equal(t, &[]pipelinePermission{
+		{Id: converter.Int(definitionID), Authorized: converter.Bool(true)},
+	}, permissions.Pipelines)
+
+	allPipelines := resourceAuthorized
+	allPipelines.DefinitionID = 0
+	allPipelines.Authorized = false
+	permissions = expandPipelinePermissions(&allPipelines)
+	require.Nil(t, permissions.Pipelines)
+	require.Equal(t, &pipelinePermission{Authorized: converter.Bool(false)}, permissions.AllPipelines)
 }
 
-var definitionResourcesArgsAuthorized = build.AuthorizeDefinitionResourcesArgs{
-	Resources:    &[]build.DefinitionResourceReference{resourceReferenceAuthorized},
-	Project:      &projectID,
-	DefinitionId: &definitionID,
+func TestResourceAuthorization_IsPipelineAuthorized(t *testing.T) {
+	permissions := &pipelinePermissions{
+		Pipelines: &[]pipelinePermission{
+			{Id: converter.Int(1), Authorized: converter.Bool(true)},
+			{Id: converter.Int(2), Authorized: converter.Bool(false)},
+		},
+	}
+	require.True(t, isPipelineAuthorized(permissions, 1))
+	require.False(t, isPipelineAuthorized(permissions, 2))
+	require.False(t, isPipelineAuthorized(permissions, 3))
+	require.False(t, isPipelineAuthorized(permissions, 0))
+
+	permissions.AllPipelines = &pipelinePermission{Authorized: converter.Bool(true)}
+	require.True(t, isPipelineAuthorized(permissions, 0))
+	require.False(t, isPipelineAuthorized(nil, 0))
 }
 
-func TestResourceAuthorization_FlattenExpand_RoundTrip(t *testing.T) {
+// verifies that repositories are addressed by the ID of their project and their own ID
+func TestResourceAuthorization_RepositoryIsQualifiedWithProjectID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := &client.AggregatedClient{CoreClient: coreClient, Ctx: context.Background()}
+
+	projectUUID := uuid.New()
+	repositoryID := uuid.New().String()
+	coreClient.
+		EXPECT().
+		GetProject(clients.Ctx, gomock.Any()).
+		Return(&core.TeamProject{Id: &projectUUID}, nil).
+		Times(1)
+
+	resourceID, err := pipelinePermissionsResourceID(clients, &authorizedResource{
+		ProjectID:  "Project Name",
+		Type:       "repository",
+		ResourceID: repositoryID,
+	})
+	require.Nil(t, err)
+	require.Equal(t, projectUUID.String()+"."+repositoryID, resourceID)
+
+	resourceID, err = pipelinePermissionsResourceID(clients, &resourceAuthorized)
+	require.Nil(t, err)
+	require.Equal(t, endpointId.String(), resourceID)
+}
+
+func TestResourceAuthorization_Import(t *testing.T) {
+	projectUUID := uuid.New().String()
+
 	resourceData := schema.TestResourceDataRaw(t, ResourceResourceAuthorization().Schema, nil)
-	flattenAuthorizedResource(resourceData, &resourceReferenceAuthorized, projectID, definitionID)
+	resourceData.SetId(projectUUID + "/Environment/12")
+	imported, err := importResourceAuthorization(resourceData, nil)
+	require.Nil(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, "12", resourceData.Id())
+	require.Equal(t, projectUUID, resourceData.Get("project_id"))
+	require.Equal(t, "environment", resourceData.Get("type"))
+	require.Equal(t, "12", resourceData.Get("resource_id"))
+	require.Equal(t, 0, resourceData.Get("definition_id"))
 
-	resourceReferenceAfterRoundtrip, projectIdAfterRoundtrip, definitionIDAfterRoundTrip := expandAuthorizedResource(resourceData)
-	require.Equal(t, resourceReferenceAuthorized, *resourceReferenceAfterRoundtrip)
-	require.Equal(t, projectID, projectIdAfterRoundtrip)
-	require.Equal(t, definitionID, definitionIDAfterRoundTrip)
+	for _, id := range []string{projectUUID + "/12", projectUUID + "/build/12", projectUUID + "//12"} {
+		resourceData.SetId(id)
+		_, err = importResourceAuthorization(resourceData, nil)
+		require.NotNil(t, err, id)
+	}
+}
+
+func getTestPipelinePermissionsClients(t *testing.T, handler http.HandlerFunc) (*client.AggregatedClient, *testhelper.RestServer) {
+	server := testhelper.NewRestServer(t, map[uuid.UUID]string{
+		pipelinePermissionsLocationID: "{project}/_apis/pipelines/pipelinePermissions/{resourceType}/{resourceId}",
+	}, handler)
+	clients := &client.AggregatedClient{
+		BuildClient: &build.ClientImpl{Client: *server.Client},
+		Ctx:         context.Background(),
+	}
+	return clients, server
 }
 
 var tests = []struct {
-	Name              string
-	DefinitionID      int
-	MockedFunction    func(*azdosdkmocks.MockBuildClientMockRecorder, *client.AggregatedClient) *gomock.Call
-	FunctionUnderTest func(*client.AggregatedClient, *schema.Resource, *schema.ResourceData) error
+	Name                string
+	DefinitionID        int
+	ExpectedMethod      string
+	ExpectedPermissions *pipelinePermissions
+	FunctionUnderTest   func(*client.AggregatedClient, *schema.Resource, *schema.ResourceData) error
 }{
 	{
-		Name: "Create project resource authorizations",
-		MockedFunction: func(mr *azdosdkmocks.MockBuildClientMockRecorder, clients *client.AggregatedClient) *gomock.Call {
-			return mr.AuthorizeProjectResources(clients.Ctx, projectResourcesArgsAuthorized)
+		Name:           "Create project resource authorizations",
+		ExpectedMethod: http.MethodPatch,
+		ExpectedPermissions: &pipelinePermissions{
+			AllPipelines: &pipelinePermission{Authorized: converter.Bool(true)},
 		},
 		FunctionUnderTest: func(clients *client.AggregatedClient, r *schema.Resource, resourceData *schema.ResourceData) error {
 			return r.Create(resourceData, clients)
 		},
 	},
 	{
-		Name:         "Create pipeline resource authorizations",
-		DefinitionID: definitionID,
-		MockedFunction: func(mr *azdosdkmocks.MockBuildClientMockRecorder, clients *client.AggregatedClient) *gomock.Call {
-			return mr.AuthorizeDefinitionResources(clients.Ctx, definitionResourcesArgsAuthorized)
+		Name:           "Create pipeline resource authorizations",
+		DefinitionID:   definitionID,
+		ExpectedMethod: http.MethodPatch,
+		ExpectedPermissions: &pipelinePermissions{
+			Pipelines: &[]pipelinePermission{{Id: converter.Int(definitionID), Authorized: converter.Bool(true)}},
 		},
 		FunctionUnderTest: func(clients *client.AggregatedClient, r *schema.Resource, resourceData *schema.ResourceData) error {
 			return r.Create(resourceData, clients)
 		},
 	},
 	{
-		Name: "Create project resource authorizations",
-		MockedFunction: func(mr *azdosdkmocks.MockBuildClientMockRecorder, clients *client.AggregatedClient) *gomock.Call {
-			return mr.AuthorizeProjectResources(clients.Ctx, projectResourcesArgsAuthorized)
+		Name:           "Update project resource authorizations",
+		ExpectedMethod: http.MethodPatch,
+		ExpectedPermissions: &pipelinePermissions{
+			AllPipelines: &pipelinePermission{Authorized: converter.Bool(true)},
 		},
 		FunctionUnderTest: func(clients *client.AggregatedClient, r *schema.Resource, resourceData *schema.ResourceData) error {
 			return r.Update(resourceData, clients)
 		},
 	},
 	{
-		Name:         "Create pipeline resource authorizations",
-		DefinitionID: definitionID,
-		MockedFunction: func(mr *azdosdkmocks.MockBuildClientMockRecorder, clients *client.AggregatedClient) *gomock.Call {
-			return mr.AuthorizeDefinitionResources(clients.Ctx, definitionResourcesArgsAuthorized)
+		Name:           "Update pipeline resource authorizations",
+		DefinitionID:   definitionID,
+		ExpectedMethod: http.MethodPatch,
+		ExpectedPermissions: &pipelinePermissions{
+			Pipelines: &[]pipelinePermission{{Id: converter.Int(definitionID), Authorized: converter.Bool(true)}},
 		},
 		FunctionUnderTest: func(clients *client.AggregatedClient, r *schema.Resource, resourceData *schema.ResourceData) error {
 			return r.Update(resourceData, clients)
 		},
 	},
 	{
-		Name: "Read project resource authorizations",
-		MockedFunction: func(mr *azdosdkmocks.MockBuildClientMockRecorder, clients *client.AggregatedClient) *gomock.Call {
-			return mr.GetProjectResources(clients.Ctx, build.GetProjectResourcesArgs{
-				Project: &projectID,
-				Type:    resourceReferenceAuthorized.Type,
-				Id:      resourceReferenceAuthorized.Id,
-			})
-		},
+		Name:           "Read project resource authorizations",
+		ExpectedMethod: http.MethodGet,
 		FunctionUnderTest: func(clients *client.AggregatedClient, r *schema.Resource, resourceData *schema.ResourceData) error {
 			return r.Read(resourceData, clients)
 		},
 	},
 	{
-		Name:         "Read pipeline resource authorizations",
-		DefinitionID: definitionID,
-		MockedFunction: func(mr *azdosdkmocks.MockBuildClientMockRecorder, clients *client.AggregatedClient) *gomock.Call {
-			return mr.GetDefinitionResources(clients.Ctx, build.GetDefinitionResourcesArgs{
-				Project:      &projectID,
-				DefinitionId: &definitionID,
-			})
-		},
+		Name:           "Read pipeline resource authorizations",
+		DefinitionID:   definitionID,
+		ExpectedMethod: http.MethodGet,
 		FunctionUnderTest: func(clients *client.AggregatedClient, r *schema.Resource, resourceData *schema.ResourceData) error {
 			return r.Read(resourceData, clients)
 		},
 	},
 	{
-		Name: "Delete project resource authorizations",
-		MockedFunction: func(mr *azdosdkmocks.MockBuildClientMockRecorder, clients *client.AggregatedClient) *gomock.Call {
-			return mr.AuthorizeProjectResources(clients.Ctx, build.AuthorizeProjectResourcesArgs{
-				Resources: &[]build.DefinitionResourceReference{resourceReferenceNotAuthorized},
-				Project:   &projectID,
-			})
+		Name:           "Delete project resource authorizations",
+		ExpectedMethod: http.MethodPatch,
+		ExpectedPermissions: &pipelinePermissions{
+			AllPipelines: &pipelinePermission{Authorized: converter.Bool(false)},
 		},
 		FunctionUnderTest: func(clients *client.AggregatedClient, r *schema.Resource, resourceData *schema.ResourceData) error {
 			return r.Delete(resourceData, clients)
 		},
 	},
 	{
-		Name:         "Delete pipeline resource authorizations",
-		DefinitionID: definitionID,
-		MockedFunction: func(mr *azdosdkmocks.MockBuildClientMockRecorder, clients *client.AggregatedClient) *gomock.Call {
-			return mr.AuthorizeDefinitionResources(clients.Ctx, build.AuthorizeDefinitionResourcesArgs{
-				Resources:    &[]build.DefinitionResourceReference{resourceReferenceNotAuthorized},
-				Project:      &projectID,
-				DefinitionId: &definitionID,
-			})
+		Name:           "Delete pipeline resource authorizations",
+		DefinitionID:   definitionID,
+		ExpectedMethod: http.MethodPatch,
+		ExpectedPermissions: &pipelinePermissions{
+			Pipelines: &[]pipelinePermission{{Id: converter.Int(definitionID), Authorized: converter.Bool(false)}},
 		},
 		FunctionUnderTest: func(clients *client.AggregatedClient, r *schema.Resource, resourceData *schema.ResourceData) error {
 			return r.Delete(resourceData, clients)
@@ -162,23 +231,67 @@ var tests = []struct {
 func TestResourceAuthorization_DoesNotSwallowError(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.Name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
+			clients, server := getTestPipelinePermissionsClients(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.ExpectedMethod, r.Method)
+				assert.Equal(t, "/"+projectID+"/_apis/pipelines/pipelinePermissions/endpoint/"+endpointId.String(), r.URL.Path)
+				if tc.ExpectedPermissions != nil {
+					var permissions pipelinePermissions
+					testhelper.ReadRestRequestBody(t, r, &permissions)
+					assert.Equal(t, tc.ExpectedPermissions.AllPipelines, permissions.AllPipelines)
+					assert.Equal(t, tc.ExpectedPermissions.Pipelines, permissions.Pipelines)
+					assert.Equal(t, &pipelinePermissionsResource{
+						Type: converter.String("endpoint"),
+						Id:   converter.String(endpointId.String()),
+					}, permissions.Resource)
+				}
+				testhelper.WriteRestError(t, w, http.StatusInternalServerError, "ResourceAuthorization Failed")
+			})
+			defer server.Close()
 
 			r := ResourceResourceAuthorization()
 			resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
-			flattenAuthorizedResource(resourceData, &resourceReferenceAuthorized, projectID, tc.DefinitionID)
-
-			buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
-			clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
-
-			tc.MockedFunction(buildClient.
-				EXPECT(), clients).
-				Return(nil, errors.New("ResourceAuthorization Failed")).
-				Times(1)
+			authorization := resourceAuthorized
+			authorization.DefinitionID = tc.DefinitionID
+			flattenAuthorizedResource(resourceData, &authorization)
 
 			err := tc.FunctionUnderTest(clients, r, resourceData)
+			require.NotNil(t, err)
 			require.Contains(t, err.Error(), "ResourceAuthorization Failed")
 		})
 	}
 }
+
+// verifies that the authorization is read back from the permissions of the pipeline
+func TestResourceAuthorization_Create_ReadsPermissions(t *testing.T) {
+	clients, server := getTestPipelinePermissionsClients(t, func(w http.ResponseWriter, r *http.Request) {
+		testhelper.WriteRestResponse(t, w, http.StatusOK, pipelinePermissions{
+			Pipelines: &[]pipelinePermission{{Id: converter.Int(definitionID), Authorized: converter.Bool(true)}},
+		})
+	})
+	defer server.Close()
+
+	r := ResourceResourceAuthorization()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenAuthorizedResource(resourceData, &resourceAuthorized)
+
+	err := r.Create(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, endpointId.String(), resourceData.Id())
+	require.True(t, resourceData.Get("authorized").(bool))
+}
+
+// verifies that the authorization is removed from the state if the resource no longer exists
+func TestResourceAuthorization_Read_RemovesDeletedResource(t *testing.T) {
+	clients, server := getTestPipelinePermissionsClients(t, func(w http.ResponseWriter, r *http.Request) {
+		testhelper.WriteRestError(t, w, http.StatusNotFound, "Resource not found")
+	})
+	defer server.Close()
+
+	r := ResourceResourceAuthorization()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenAuthorizedResource(resourceData, &resourceAuthorized)
+
+	err := r.Read(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
@@ -0,0 +1,243 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+const (
+	refsPrefix    = "refs/"
+	headsPrefix   = "refs/heads/"
+	tagsPrefix    = "refs/tags/"
+	emptyObjectID = "0000000000000000000000000000000000000000"
+)
+
+var (
+	regexpRefsPrefix = regexp.MustCompile(`^refs/`)
+	regexpBranchRef  = regexp.MustCompile(`^refs/heads/.+$`)
+	regexpCommitID   = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+)
+
+// withoutRefsPrefix strips the leading "refs/" of a fully qualified ref name. The REST API
+// expects filters like "heads/master" instead of "refs/heads/master".
+func withoutRefsPrefix(refName string) string {
+	return strings.TrimPrefix(refName, refsPrefix)
+}
+
+// withHeadsPrefix converts a branch name like "master" to a fully qualified ref name like "refs/heads/master"
+func withHeadsPrefix(branchName string) string {
+	if strings.HasPrefix(branchName, headsPrefix) {
+		return branchName
+	}
+	return headsPrefix + branchName
+}
+
+// withTagsPrefix converts a tag name like "v1.0" to a fully qualified ref name like "refs/tags/v1.0"
+func withTagsPrefix(tagName string) string {
+	if strings.HasPrefix(tagName, tagsPrefix) {
+		return tagName
+	}
+	return tagsPrefix + tagName
+}
+
+// suppressHeadsPrefixDifference suppresses the diff of a branch name which is configured with and stored
+// without the refs/heads/ prefix or vice versa
+func suppressHeadsPrefixDifference(k, old, new string, d *schema.ResourceData) bool {
+	return withHeadsPrefix(old) == withHeadsPrefix(new)
+}
+
+// suppressTagsPrefixDifference suppresses the diff of a tag name which is configured with and stored
+// without the refs/tags/ prefix or vice versa
+func suppressTagsPrefixDifference(k, old, new string, d *schema.ResourceData) bool {
+	return withTagsPrefix(old) == withTagsPrefix(new)
+}
+
+// suppressSourceRefDiffAfterImport suppresses the diff of an attribute describing the source of a ref.
+// The source is only used while creating the ref and cannot be derived from an imported ref.
+func suppressSourceRefDiffAfterImport(k, old, new string, d *schema.ResourceData) bool {
+	return old == "" && d.Id() != ""
+}
+
+// getGitRef looks up a single ref by its fully qualified name. The GetRefs API only supports
+// "starts with" filtering, so the result has to be matched exactly. Annotated tags are peeled,
+// the ID of the commit they point to is available as PeeledObjectId. A nil ref is returned
+// if the ref does not exist.
+func getGitRef(clients *client.AggregatedClient, projectID string, repositoryID string, refName string) (*git.GitRef, error) {
+	refs, err := clients.GitReposClient.GetRefs(clients.Ctx, git.GetRefsArgs{
+		RepositoryId: converter.String(repositoryID),
+		Project:      converter.String(projectID),
+		Filter:       converter.String(withoutRefsPrefix(refName)),
+		PeelTags:     converter.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if refs == nil {
+		return nil, nil
+	}
+
+	for _, ref := range refs.Value {
+		if strings.EqualFold(converter.ToString(ref.Name, ""), refName) {
+			return &ref, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveGitCommitID returns the ID of the commit referenced by either a branch, a tag or a commit ID.
+// Exactly one of the references is expected to be non-empty.
+func resolveGitCommitID(clients *client.AggregatedClient, projectID string, repositoryID string, branch string, tag string, commitID string) (string, error) {
+	if commitID != "" {
+		return strings.ToLower(commitID), nil
+	}
+
+	var refName string
+	if branch != "" {
+		refName = withHeadsPrefix(branch)
+	} else if tag != "" {
+		refName = withTagsPrefix(tag)
+	} else {
+		return "", fmt.Errorf("Either a branch, a tag or a commit ID must be specified")
+	}
+
+	ref, err := getGitRef(clients, projectID, repositoryID, refName)
+	if err != nil {
+		return "", fmt.Errorf("Error reading ref %s in repository %s: %+v", refName, repositoryID, err)
+	}
+	if ref == nil {
+		return "", fmt.Errorf("Ref %s does not exist in repository %s", refName, repositoryID)
+	}
+
+	// annotated tags point to a tag object instead of a commit
+	if peeledObjectID := converter.ToString(ref.PeeledObjectId, ""); peeledObjectID != "" {
+		return peeledObjectID, nil
+	}
+	return converter.ToString(ref.ObjectId, ""), nil
+}
+
+// deleteGitRef deletes a ref pointing to the given object
+func deleteGitRef(clients *client.AggregatedClient, projectID string, repositoryID string, refName string, objectID *string) error {
+	results, err := clients.GitReposClient.UpdateRefs(clients.Ctx, git.UpdateRefsArgs{
+		RefUpdates: &[]git.GitRefUpdate{
+			{
+				Name:        converter.String(refName),
+				OldObjectId: objectID,
+				NewObjectId: converter.String(emptyObjectID),
+			},
+		},
+		RepositoryId: converter.String(repositoryID),
+		Project:      converter.String(projectID),
+	})
+	if err != nil {
+		return err
+	}
+	return validateRefUpdateResults(results)
+}
+
+// createGitRef creates a new ref pointing to the given object
+func createGitRef(clients *client.AggregatedClient, projectID string, repositoryID string, refName string, objectID string) error {
+	results, err := clients.GitReposClient.UpdateRefs(clients.Ctx, git.UpdateRefsArgs{
+		RefUpdates: &[]git.GitRefUpdate{
+			{
+				Name:        converter.String(refName),
+				OldObjectId: converter.String(emptyObjectID),
+				NewObjectId: converter.String(objectID),
+			},
+		},
+		RepositoryId: converter.String(repositoryID),
+		Project:      converter.String(projectID),
+	})
+	if err != nil {
+		return err
+	}
+	return validateRefUpdateResults(results)
+}
+
+// validateRefUpdateResults returns an error for the first ref update which has been rejected by the service
+func validateRefUpdateResults(results *[]git.GitRefUpdateResult) error {
+	if results == nil {
+		return nil
+	}
+	for _, result := range *results {
+		if converter.ToBool(result.Success, false) {
+			continue
+		}
+		updateStatus := ""
+		if result.UpdateStatus != nil {
+			updateStatus = string(*result.UpdateStatus)
+		}
+		return fmt.Errorf("Update of ref %s failed with status %s. %s",
+			converter.ToString(result.Name, ""),
+			updateStatus,
+			converter.ToString(result.CustomMessage, ""))
+	}
+	return nil
+}
+
+// parseGitRefImportID parses an import ID of a ref scoped resource that looks like one of the following:
+//
+//	<project ID>/<repository ID>/<ref>
+//	<project name>/<repository name>/<ref>
+//
+// The project and repository are resolved to their IDs.
+func parseGitRefImportID(id string, m interface{}) (string, string, string, error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format of ID (%s), expected projectid/repositoryId/ref", id)
+	}
+
+	projectID, err := tfhelper.GetRealProjectId(parts[0], m)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	clients := m.(*client.AggregatedClient)
+	repo, err := gitRepositoryRead(clients, parts[1], "", projectID)
+	if err != nil {
+		return "", "", "", fmt.Errorf("Error reading repository %s in project %s: %+v", parts[1], projectID, err)
+	}
+	return projectID, repo.Id.String(), parts[2], nil
+}
+
+// waitForGitAsyncOperation polls the status of an asynchronous operation like an import or a fork sync
+// until the operation has completed. Failed and abandoned operations are reported with the error message
+// returned by getStatus.
+func waitForGitAsyncOperation(timeout time.Duration, getStatus func() (*git.GitAsyncOperationStatus, string, error)) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			string(git.GitAsyncOperationStatusValues.Queued),
+			string(git.GitAsyncOperationStatusValues.InProgress),
+		},
+		Target: []string{
+			string(git.GitAsyncOperationStatusValues.Completed),
+		},
+		Refresh: func() (interface{}, string, error) {
+			status, errorMessage, err := getStatus()
+			if err != nil {
+				return nil, "", err
+			}
+			if status == nil {
+				return status, string(git.GitAsyncOperationStatusValues.Queued), nil
+			}
+			if *status == git.GitAsyncOperationStatusValues.Failed || *status == git.GitAsyncOperationStatusValues.Abandoned {
+				return nil, "", fmt.Errorf("Operation %s: %s", *status, errorMessage)
+			}
+			return status, string(*status), nil
+		},
+		Timeout:                   timeout,
+		MinTimeout:                5 * time.Second,
+		Delay:                     2 * time.Second,
+		ContinuousTargetOccurence: 1,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
@@ -0,0 +1,161 @@
+package git
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+)
+
+// DataGitRepositoryBranches schema and implementation for the refs of a Git repository data source
+func DataGitRepositoryBranches() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGitRepositoryBranchesRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"repository_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.IsUUID,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      headsPrefix,
+				ValidateFunc: validation.StringMatch(regexpRefsPrefix, "Prefix must start with refs/"),
+			},
+			"contains": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"branches": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creator": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_locked": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_locked_by": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGitRepositoryBranchesRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	prefix := d.Get("prefix").(string)
+	contains := d.Get("contains").(string)
+
+	refs, err := getGitRefsForPrefixAndContains(clients, projectID, repositoryID, prefix, contains)
+	if err != nil {
+		return fmt.Errorf("Error finding refs with prefix %s in repository %s. Error: %v", prefix, repositoryID, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] refs from repository %s", len(refs), repositoryID)
+
+	h := sha1.New()
+	if _, err := h.Write([]byte(strings.Join([]string{repositoryID, prefix, contains}, "#"))); err != nil {
+		return fmt.Errorf("Unable to compute hash for refs filter: %v", err)
+	}
+	d.SetId("branches#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+
+	err = d.Set("branches", flattenGitRefs(refs))
+	if err != nil {
+		return fmt.Errorf("Error setting refs of repository %s: %v", repositoryID, err)
+	}
+	return nil
+}
+
+func flattenGitRefs(refs []git.GitRef) []interface{} {
+	results := make([]interface{}, 0, len(refs))
+	for _, ref := range refs {
+		output := map[string]interface{}{
+			"name":      converter.ToString(ref.Name, ""),
+			"object_id": converter.ToString(ref.ObjectId, ""),
+			"is_locked": converter.ToBool(ref.IsLocked, false),
+		}
+		if ref.Creator != nil {
+			output["creator"] = converter.ToString(ref.Creator.DisplayName, "")
+		}
+		if ref.IsLockedBy != nil {
+			output["is_locked_by"] = converter.ToString(ref.IsLockedBy.DisplayName, "")
+		}
+		results = append(results, output)
+	}
+	return results
+}
+
+func getGitRefsForPrefixAndContains(clients *client.AggregatedClient, projectID string, repositoryID string, prefix string, contains string) ([]git.GitRef, error) {
+	var refs []git.GitRef
+	var currentToken string
+
+	for hasMore := true; hasMore; {
+		newRefs, latestToken, err := getGitRefsWithContinuationToken(clients, projectID, repositoryID, prefix, contains, currentToken)
+		currentToken = latestToken
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Received [%d] refs; Continuation token [%s]", len(newRefs), currentToken)
+
+		refs = append(refs, newRefs...)
+		hasMore = currentToken != ""
+	}
+
+	return refs, nil
+}
+
+func getGitRefsWithContinuationToken(clients *client.AggregatedClient, projectID string, repositoryID string, prefix string, contains string, continuationToken string) ([]git.GitRef, string, error) {
+	args := git.GetRefsArgs{
+		RepositoryId: converter.String(repositoryID),
+		Project:      converter.String(projectID),
+		Filter:       converter.String(withoutRefsPrefix(prefix)),
+	}
+	if contains != "" {
+		args.FilterContains = converter.String(contains)
+	}
+	if continuationToken != "" {
+		args.ContinuationToken = &continuationToken
+	}
+
+	response, err := clients.GitReposClient.GetRefs(clients.Ctx, args)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.Value, response.ContinuationToken, nil
+}
@@ -0,0 +1,113 @@
+// +build all git data_sources data_git_repository_branches
+// +build !exclude_data_sources !exclude_git !exclude_data_git_repository_branches
+
+package git
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceGitRepositoryBranches_Read_PagesThroughRefs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{
+		GitReposClient: reposClient,
+		Ctx:            context.Background(),
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, DataGitRepositoryBranches().Schema, nil)
+	resourceData.Set("project_id", "project")
+	resourceData.Set("repository_id", "repository")
+	resourceData.Set("contains", "release")
+
+	firstPage := reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, git.GetRefsArgs{
+			RepositoryId:   converter.String("repository"),
+			Project:        converter.String("project"),
+			Filter:         converter.String("heads/"),
+			FilterContains: converter.String("release"),
+		}).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{
+					Name:     converter.String("refs/heads/release/1.0"),
+					ObjectId: converter.String("1111111111111111111111111111111111111111"),
+					Creator:  &webapi.IdentityRef{DisplayName: converter.String("creator")},
+				},
+			},
+			ContinuationToken: "next",
+		}, nil).
+		Times(1)
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, git.GetRefsArgs{
+			RepositoryId:      converter.String("repository"),
+			Project:           converter.String("project"),
+			Filter:            converter.String("heads/"),
+			FilterContains:    converter.String("release"),
+			ContinuationToken: converter.String("next"),
+		}).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{
+					Name:       converter.String("refs/heads/release/2.0"),
+					ObjectId:   converter.String("2222222222222222222222222222222222222222"),
+					IsLocked:   converter.Bool(true),
+					IsLockedBy: &webapi.IdentityRef{DisplayName: converter.String("locker")},
+				},
+			},
+		}, nil).
+		After(firstPage).
+		Times(1)
+
+	err := dataSourceGitRepositoryBranchesRead(resourceData, clients)
+	require.Nil(t, err)
+
+	branches := resourceData.Get("branches").([]interface{})
+	require.Len(t, branches, 2)
+	require.Equal(t, "refs/heads/release/1.0", branches[0].(map[string]interface{})["name"])
+	require.Equal(t, "creator", branches[0].(map[string]interface{})["creator"])
+	require.False(t, branches[0].(map[string]interface{})["is_locked"].(bool))
+	require.Equal(t, "refs/heads/release/2.0", branches[1].(map[string]interface{})["name"])
+	require.True(t, branches[1].(map[string]interface{})["is_locked"].(bool))
+	require.Equal(t, "locker", branches[1].(map[string]interface{})["is_locked_by"])
+}
+
+func TestDataSourceGitRepositoryBranches_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{
+		GitReposClient: reposClient,
+		Ctx:            context.Background(),
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, DataGitRepositoryBranches().Schema, nil)
+	resourceData.Set("project_id", "project")
+	resourceData.Set("repository_id", "repository")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetRefs() Failed")).
+		Times(1)
+
+	err := dataSourceGitRepositoryBranchesRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetRefs() Failed")
+}
@@ -0,0 +1,163 @@
+package git
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+)
+
+// DataGitRepositoryFile schema and implementation for the content of a file in a Git repository data source
+func DataGitRepositoryFile() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGitRepositoryFileRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"repository_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.IsUUID,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"file": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"branch": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				ConflictsWith: []string{"tag", "commit_id"},
+			},
+			"tag": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				ConflictsWith: []string{"branch", "commit_id"},
+			},
+			"commit_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringMatch(regexpCommitID, "Commit ID must be a full SHA-1 hash"),
+				ConflictsWith: []string{"branch", "tag"},
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_binary": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"object_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_commit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGitRepositoryFileRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	file := d.Get("file").(string)
+	versionDescriptor := expandGitRepositoryFileVersion(d)
+
+	item, err := clients.GitReposClient.GetItem(clients.Ctx, git.GetItemArgs{
+		RepositoryId:           converter.String(repositoryID),
+		Project:                converter.String(projectID),
+		Path:                   converter.String(file),
+		IncludeContentMetadata: converter.Bool(true),
+		VersionDescriptor:      versionDescriptor,
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return fmt.Errorf("File %s does not exist in repository %s", file, repositoryID)
+		}
+		return fmt.Errorf("Error reading file %s in repository %s: %+v", file, repositoryID, err)
+	}
+	if converter.ToBool(item.IsFolder, false) {
+		return fmt.Errorf("%s is a folder in repository %s", file, repositoryID)
+	}
+
+	reader, err := clients.GitReposClient.GetItemContent(clients.Ctx, git.GetItemContentArgs{
+		RepositoryId:      converter.String(repositoryID),
+		Project:           converter.String(projectID),
+		Path:              converter.String(file),
+		VersionDescriptor: versionDescriptor,
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading content of file %s in repository %s: %+v", file, repositoryID, err)
+	}
+	defer reader.Close()
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("Error reading content of file %s in repository %s: %+v", file, repositoryID, err)
+	}
+
+	// Terraform strings must be valid UTF-8, so binary files are returned base64 encoded
+	isBinary := isBinaryGitRepositoryFile(item, content)
+	if isBinary {
+		d.Set("content", base64.StdEncoding.EncodeToString(content))
+	} else {
+		d.Set("content", string(content))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s:%s", repositoryID, file, converter.ToString(item.CommitId, "")))
+	d.Set("is_binary", isBinary)
+	d.Set("object_id", item.ObjectId)
+	d.Set("last_commit_id", item.CommitId)
+	return nil
+}
+
+// expandGitRepositoryFileVersion returns nil if no version is configured, the service uses the default branch in this case
+func expandGitRepositoryFileVersion(d *schema.ResourceData) *git.GitVersionDescriptor {
+	if branch, ok := d.GetOk("branch"); ok {
+		return &git.GitVersionDescriptor{
+			Version:     converter.String(strings.TrimPrefix(branch.(string), headsPrefix)),
+			VersionType: &git.GitVersionTypeValues.Branch,
+		}
+	}
+	if tag, ok := d.GetOk("tag"); ok {
+		return &git.GitVersionDescriptor{
+			Version:     converter.String(strings.TrimPrefix(tag.(string), tagsPrefix)),
+			VersionType: &git.GitVersionTypeValues.Tag,
+		}
+	}
+	if commitID, ok := d.GetOk("commit_id"); ok {
+		return &git.GitVersionDescriptor{
+			Version:     converter.String(commitID.(string)),
+			VersionType: &git.GitVersionTypeValues.Commit,
+		}
+	}
+	return nil
+}
+
+func isBinaryGitRepositoryFile(item *git.GitItem, content []byte) bool {
+	if item.ContentMetadata != nil && converter.ToBool(item.ContentMetadata.IsBinary, false) {
+		return true
+	}
+	return !utf8.Valid(content) || bytes.IndexByte(content, 0) >= 0
+}
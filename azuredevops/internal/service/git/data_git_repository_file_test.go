@@ -0,0 +1,87 @@
+// +build all git data_sources data_git_repository_file
+// +build !exclude_data_sources !exclude_git !exclude_data_git_repository_file
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func readGitRepositoryFileDataSource(t *testing.T, content []byte) *schema.ResourceData {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{
+		GitReposClient: reposClient,
+		Ctx:            context.Background(),
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, DataGitRepositoryFile().Schema, nil)
+	resourceData.Set("project_id", "project")
+	resourceData.Set("repository_id", "repository")
+	resourceData.Set("file", "/VERSION")
+	resourceData.Set("tag", "refs/tags/v1.0.0")
+
+	versionDescriptor := &git.GitVersionDescriptor{
+		Version:     converter.String("v1.0.0"),
+		VersionType: &git.GitVersionTypeValues.Tag,
+	}
+	reposClient.
+		EXPECT().
+		GetItem(clients.Ctx, git.GetItemArgs{
+			RepositoryId:           converter.String("repository"),
+			Project:                converter.String("project"),
+			Path:                   converter.String("/VERSION"),
+			IncludeContentMetadata: converter.Bool(true),
+			VersionDescriptor:      versionDescriptor,
+		}).
+		Return(&git.GitItem{
+			ObjectId: converter.String("1111111111111111111111111111111111111111"),
+			CommitId: converter.String("2222222222222222222222222222222222222222"),
+		}, nil).
+		Times(1)
+	reposClient.
+		EXPECT().
+		GetItemContent(clients.Ctx, git.GetItemContentArgs{
+			RepositoryId:      converter.String("repository"),
+			Project:           converter.String("project"),
+			Path:              converter.String("/VERSION"),
+			VersionDescriptor: versionDescriptor,
+		}).
+		Return(ioutil.NopCloser(bytes.NewReader(content)), nil).
+		Times(1)
+
+	err := dataSourceGitRepositoryFileRead(resourceData, clients)
+	require.Nil(t, err)
+	return resourceData
+}
+
+func TestDataSourceGitRepositoryFile_Read_ReturnsTextContent(t *testing.T) {
+	resourceData := readGitRepositoryFileDataSource(t, []byte("1.0.0\n"))
+
+	require.Equal(t, "1.0.0\n", resourceData.Get("content"))
+	require.False(t, resourceData.Get("is_binary").(bool))
+	require.Equal(t, "1111111111111111111111111111111111111111", resourceData.Get("object_id"))
+	require.Equal(t, "2222222222222222222222222222222222222222", resourceData.Get("last_commit_id"))
+}
+
+func TestDataSourceGitRepositoryFile_Read_EncodesBinaryContent(t *testing.T) {
+	content := []byte{0x89, 0x50, 0x4e, 0x47, 0x00, 0xff}
+	resourceData := readGitRepositoryFileDataSource(t, content)
+
+	require.Equal(t, base64.StdEncoding.EncodeToString(content), resourceData.Get("content"))
+	require.True(t, resourceData.Get("is_binary").(bool))
+}
@@ -0,0 +1,95 @@
+package git
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// A helper type holding the configuration of the fork_sync block
+type repoForkSyncMeta struct {
+	sourceBranch string
+	targetBranch string
+}
+
+func expandGitRepositoryForkSync(d *schema.ResourceData) *repoForkSyncMeta {
+	forkSyncData := d.Get("fork_sync").([]interface{})
+	if len(forkSyncData) != 1 || forkSyncData[0] == nil {
+		return nil
+	}
+
+	forkSyncValues := forkSyncData[0].(map[string]interface{})
+	forkSync := &repoForkSyncMeta{
+		sourceBranch: withHeadsPrefix(forkSyncValues["source_branch"].(string)),
+		targetBranch: forkSyncValues["target_branch"].(string),
+	}
+	if forkSync.targetBranch == "" {
+		forkSync.targetBranch = forkSync.sourceBranch
+	} else {
+		forkSync.targetBranch = withHeadsPrefix(forkSync.targetBranch)
+	}
+	return forkSync
+}
+
+// syncGitRepositoryFork updates the target branch of the fork with the source branch of its parent repository
+// and waits until the synchronization has finished. The final status of the sync request is returned.
+func syncGitRepositoryFork(clients *client.AggregatedClient, repositoryID string, projectID string, parentRepositoryID string, forkSync *repoForkSyncMeta, timeout time.Duration) (string, error) {
+	parentRepo, err := gitRepositoryRead(clients, parentRepositoryID, "", "")
+	if err != nil {
+		return "", fmt.Errorf("Failed to locate parent repository [%s]: %+v", parentRepositoryID, err)
+	}
+	if parentRepo.Project == nil {
+		return "", fmt.Errorf("Unable to determine the project of parent repository [%s]", parentRepositoryID)
+	}
+
+	syncRequest, err := clients.GitReposClient.CreateForkSyncRequest(clients.Ctx, git.CreateForkSyncRequestArgs{
+		SyncParams: &git.GitForkSyncRequestParameters{
+			Source: &git.GlobalGitRepositoryKey{
+				ProjectId:    parentRepo.Project.Id,
+				RepositoryId: parentRepo.Id,
+			},
+			SourceToTargetRefs: &[]git.SourceToTargetRef{
+				{
+					SourceRef: converter.String(forkSync.sourceBranch),
+					TargetRef: converter.String(forkSync.targetBranch),
+				},
+			},
+		},
+		RepositoryNameOrId: converter.String(repositoryID),
+		Project:            converter.String(projectID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error creating fork sync request: %+v", err)
+	}
+	if syncRequest.OperationId == nil {
+		return "", fmt.Errorf("The service did not return the ID of the fork sync request")
+	}
+
+	status := git.GitAsyncOperationStatusValues.Queued
+	err = waitForGitAsyncOperation(timeout, func() (*git.GitAsyncOperationStatus, string, error) {
+		syncRequest, err := clients.GitReposClient.GetForkSyncRequest(clients.Ctx, git.GetForkSyncRequestArgs{
+			RepositoryNameOrId:  converter.String(repositoryID),
+			ForkSyncOperationId: syncRequest.OperationId,
+			Project:             converter.String(projectID),
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("Error reading fork sync request: %+v", err)
+		}
+		if syncRequest.Status != nil {
+			status = *syncRequest.Status
+		}
+		errorMessage := ""
+		if syncRequest.DetailedStatus != nil {
+			errorMessage = converter.ToString(syncRequest.DetailedStatus.ErrorMessage, "")
+		}
+		return syncRequest.Status, errorMessage, nil
+	})
+	if err != nil {
+		return string(status), fmt.Errorf("Error waiting for fork sync request %d of repository %s: %+v", *syncRequest.OperationId, repositoryID, err)
+	}
+	return string(status), nil
+}
@@ -0,0 +1,176 @@
+package git
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// Settings like forking are not part of the repository model, the service stores them
+// as a policy configuration scoped to the repository.
+var gitRepositorySettingsPolicyType = uuid.MustParse("0517f88d-4ec5-4343-9d26-9930ebd53069")
+
+const allowedForkTargetsSetting = "allowedForkTargets"
+
+var gitRepositoriesLocationID = uuid.MustParse("225f7195-f9c7-4d14-ab28-a83f7ff77e1f")
+
+// The isDisabled property is not part of the repository model of the SDK
+type gitRepositoryDisabledUpdate struct {
+	IsDisabled *bool `json:"isDisabled"`
+}
+
+// using: PATCH https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}?api-version=6.0
+func updateGitRepositoryIsDisabled(clients *client.AggregatedClient, projectID string, repositoryID string, isDisabled bool) error {
+	return utils.SendRestRequest(clients.Ctx, clients.GitReposClient, &utils.RestRequest{
+		Method:     http.MethodPatch,
+		LocationID: gitRepositoriesLocationID,
+		APIVersion: "6.0",
+		RouteValues: map[string]string{
+			"project":      projectID,
+			"repositoryId": repositoryID,
+		},
+		Body: gitRepositoryDisabledUpdate{IsDisabled: converter.Bool(isDisabled)},
+	}, nil)
+}
+
+// findDisabledGitRepository looks up a repository which cannot be read directly because it has been disabled.
+// Disabled repositories are only returned when listing the hidden repositories of a project.
+func findDisabledGitRepository(clients *client.AggregatedClient, repoID string, repoName string, projectID string) (*git.GitRepository, error) {
+	repos, err := clients.GitReposClient.GetRepositories(clients.Ctx, git.GetRepositoriesArgs{
+		Project:       converter.String(projectID),
+		IncludeHidden: converter.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if repos == nil {
+		return nil, nil
+	}
+
+	for _, repo := range *repos {
+		if repoID != "" && repo.Id != nil && strings.EqualFold(repo.Id.String(), repoID) {
+			return &repo, nil
+		}
+		if repoID == "" && strings.EqualFold(converter.ToString(repo.Name, ""), repoName) {
+			return &repo, nil
+		}
+	}
+	return nil, nil
+}
+
+// getGitRepositorySettingsPolicy returns the settings policy of a repository or nil if the repository uses the default settings
+func getGitRepositorySettingsPolicy(clients *client.AggregatedClient, projectID string, repositoryID string) (*policy.PolicyConfiguration, error) {
+	continuationToken := ""
+	for {
+		args := policy.GetPolicyConfigurationsArgs{
+			Project:    converter.String(projectID),
+			PolicyType: &gitRepositorySettingsPolicyType,
+		}
+		if continuationToken != "" {
+			args.ContinuationToken = converter.String(continuationToken)
+		}
+
+		configurations, err := clients.PolicyClient.GetPolicyConfigurations(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, configuration := range configurations.Value {
+			if converter.ToBool(configuration.IsDeleted, false) {
+				continue
+			}
+			if gitRepositorySettingsPolicyHasScope(&configuration, repositoryID) {
+				return &configuration, nil
+			}
+		}
+
+		continuationToken = configurations.ContinuationToken
+		if continuationToken == "" {
+			return nil, nil
+		}
+	}
+}
+
+func gitRepositorySettingsPolicyHasScope(configuration *policy.PolicyConfiguration, repositoryID string) bool {
+	settings, ok := configuration.Settings.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	scopes, ok := settings["scope"].([]interface{})
+	if !ok {
+		return false
+	}
+	for _, scope := range scopes {
+		scopeMap, ok := scope.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := scopeMap["repositoryId"].(string); ok && strings.EqualFold(id, repositoryID) {
+			return true
+		}
+	}
+	return false
+}
+
+// readGitRepositoryAllowForks returns true, the service default, if no settings policy exists for the repository
+func readGitRepositoryAllowForks(clients *client.AggregatedClient, projectID string, repositoryID string) (bool, error) {
+	configuration, err := getGitRepositorySettingsPolicy(clients, projectID, repositoryID)
+	if err != nil || configuration == nil {
+		return true, err
+	}
+
+	settings := configuration.Settings.(map[string]interface{})
+	if value, ok := settings[allowedForkTargetsSetting].(float64); ok {
+		return value != 0, nil
+	}
+	return true, nil
+}
+
+func updateGitRepositoryAllowForks(clients *client.AggregatedClient, projectID string, repositoryID string, allowForks bool) error {
+	allowedForkTargets := 0
+	if allowForks {
+		allowedForkTargets = 1
+	}
+
+	configuration, err := getGitRepositorySettingsPolicy(clients, projectID, repositoryID)
+	if err != nil {
+		return err
+	}
+
+	if configuration == nil {
+		_, err = clients.PolicyClient.CreatePolicyConfiguration(clients.Ctx, policy.CreatePolicyConfigurationArgs{
+			Project: converter.String(projectID),
+			Configuration: &policy.PolicyConfiguration{
+				IsEnabled:  converter.Bool(true),
+				IsBlocking: converter.Bool(false),
+				Type: &policy.PolicyTypeRef{
+					Id: &gitRepositorySettingsPolicyType,
+				},
+				Settings: map[string]interface{}{
+					allowedForkTargetsSetting: allowedForkTargets,
+					"scope": []map[string]interface{}{
+						{
+							"repositoryId": repositoryID,
+						},
+					},
+				},
+			},
+		})
+		return err
+	}
+
+	settings := configuration.Settings.(map[string]interface{})
+	settings[allowedForkTargetsSetting] = allowedForkTargets
+	_, err = clients.PolicyClient.UpdatePolicyConfiguration(clients.Ctx, policy.UpdatePolicyConfigurationArgs{
+		Project:         converter.String(projectID),
+		ConfigurationId: configuration.Id,
+		Configuration:   configuration,
+	})
+	return err
+}
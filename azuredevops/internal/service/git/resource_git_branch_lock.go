@@ -0,0 +1,141 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+)
+
+// ResourceGitBranchLock schema and implementation for locking a branch of a git repository
+func ResourceGitBranchLock() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitBranchLockCreate,
+		Read:   resourceGitBranchLockRead,
+		Delete: resourceGitBranchLockDelete,
+		Importer: &schema.ResourceImporter{
+			State: importGitBranchLock,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"repository_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.IsUUID,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"ref": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexpBranchRef, "Ref must be a fully qualified branch name starting with refs/heads/"),
+			},
+			"is_locked_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGitBranchLockCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	refName := d.Get("ref").(string)
+
+	err := updateGitRefLock(clients, projectID, repositoryID, refName, true)
+	if err != nil {
+		return fmt.Errorf("Error locking ref %s in repository %s: %+v", refName, repositoryID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", repositoryID, refName))
+	return resourceGitBranchLockRead(d, m)
+}
+
+func resourceGitBranchLockRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	refName := d.Get("ref").(string)
+
+	ref, err := getGitRef(clients, projectID, repositoryID, refName)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading ref %s in repository %s: %+v", refName, repositoryID, err)
+	}
+
+	// The lock has been removed if either the ref has been deleted or the ref
+	// has been unlocked outside of Terraform.
+	if ref == nil || !converter.ToBool(ref.IsLocked, false) {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("ref", ref.Name)
+	if ref.IsLockedBy != nil {
+		d.Set("is_locked_by", ref.IsLockedBy.DisplayName)
+	}
+	return nil
+}
+
+func resourceGitBranchLockDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	refName := d.Get("ref").(string)
+
+	err := updateGitRefLock(clients, projectID, repositoryID, refName, false)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error unlocking ref %s in repository %s: %+v", refName, repositoryID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func updateGitRefLock(clients *client.AggregatedClient, projectID string, repositoryID string, refName string, isLocked bool) error {
+	_, err := clients.GitReposClient.UpdateRef(clients.Ctx, git.UpdateRefArgs{
+		NewRefInfo: &git.GitRefUpdate{
+			IsLocked: converter.Bool(isLocked),
+		},
+		RepositoryId: converter.String(repositoryID),
+		Project:      converter.String(projectID),
+		Filter:       converter.String(withoutRefsPrefix(refName)),
+	})
+	return err
+}
+
+func importGitBranchLock(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	projectID, repositoryID, refName, err := parseGitRefImportID(d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("repository_id", repositoryID)
+	// the ref is configured with the refs/heads/ prefix, but the ID may contain the branch name only
+	refName = withHeadsPrefix(refName)
+	d.Set("ref", refName)
+	d.SetId(fmt.Sprintf("%s:%s", repositoryID, refName))
+	return []*schema.ResourceData{d}, nil
+}
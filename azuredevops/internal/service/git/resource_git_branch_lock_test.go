@@ -0,0 +1,178 @@
+// +build all git resource_git_branch_lock
+// +build !exclude_git !exclude_resource_git_branch_lock
+
+package git
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testBranchLockProjectID = uuid.New().String()
+var testBranchLockRepoID = uuid.New().String()
+
+const testBranchLockRef = "refs/heads/master"
+
+func getBranchLockResourceData(t *testing.T) *schema.ResourceData {
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitBranchLock().Schema, nil)
+	resourceData.Set("project_id", testBranchLockProjectID)
+	resourceData.Set("repository_id", testBranchLockRepoID)
+	resourceData.Set("ref", testBranchLockRef)
+	return resourceData
+}
+
+// verifies that the create operation is considered failed if the lock API call fails
+func TestGitBranchLock_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getBranchLockResourceData(t)
+
+	expectedArgs := git.UpdateRefArgs{
+		NewRefInfo: &git.GitRefUpdate{
+			IsLocked: converter.Bool(true),
+		},
+		RepositoryId: converter.String(testBranchLockRepoID),
+		Project:      converter.String(testBranchLockProjectID),
+		Filter:       converter.String("heads/master"),
+	}
+	reposClient.
+		EXPECT().
+		UpdateRef(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateRef() Failed")).
+		Times(1)
+
+	err := resourceGitBranchLockCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "UpdateRef() Failed")
+}
+
+// verifies that a ref which has been unlocked outside of Terraform is removed from the state
+func TestGitBranchLock_Read_RemovesUnlockedRefFromState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getBranchLockResourceData(t)
+	resourceData.SetId(testBranchLockRepoID + ":" + testBranchLockRef)
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{Name: converter.String("refs/heads/master-old"), IsLocked: converter.Bool(true)},
+				{Name: converter.String(testBranchLockRef), IsLocked: converter.Bool(false)},
+			},
+		}, nil).
+		Times(1)
+
+	err := resourceGitBranchLockRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that a locked ref is kept in the state
+func TestGitBranchLock_Read_KeepsLockedRef(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getBranchLockResourceData(t)
+	resourceData.SetId(testBranchLockRepoID + ":" + testBranchLockRef)
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{
+					Name:       converter.String(testBranchLockRef),
+					IsLocked:   converter.Bool(true),
+					IsLockedBy: &webapi.IdentityRef{DisplayName: converter.String("Jane Doe")},
+				},
+			},
+		}, nil).
+		Times(1)
+
+	err := resourceGitBranchLockRead(resourceData, clients)
+	require.Nil(t, err)
+	require.NotEqual(t, "", resourceData.Id())
+	require.Equal(t, "Jane Doe", resourceData.Get("is_locked_by"))
+}
+
+// verifies that the delete operation unlocks the ref
+func TestGitBranchLock_Delete_UnlocksRef(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getBranchLockResourceData(t)
+	resourceData.SetId(testBranchLockRepoID + ":" + testBranchLockRef)
+
+	expectedArgs := git.UpdateRefArgs{
+		NewRefInfo: &git.GitRefUpdate{
+			IsLocked: converter.Bool(false),
+		},
+		RepositoryId: converter.String(testBranchLockRepoID),
+		Project:      converter.String(testBranchLockProjectID),
+		Filter:       converter.String("heads/master"),
+	}
+	reposClient.
+		EXPECT().
+		UpdateRef(clients.Ctx, expectedArgs).
+		Return(&git.GitRef{}, nil).
+		Times(1)
+
+	err := resourceGitBranchLockDelete(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that an import ID with the branch name only is normalized to the ref of the branch
+func TestGitBranchLock_Import_AddsHeadsPrefixToRef(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	repoID := uuid.MustParse(testBranchLockRepoID)
+	reposClient.
+		EXPECT().
+		GetRepository(clients.Ctx, git.GetRepositoryArgs{
+			RepositoryId: converter.String(testBranchLockRepoID),
+			Project:      converter.String(testBranchLockProjectID),
+		}).
+		Return(&git.GitRepository{Id: &repoID}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitBranchLock().Schema, nil)
+	resourceData.SetId(testBranchLockProjectID + "/" + testBranchLockRepoID + "/master")
+
+	imported, err := importGitBranchLock(resourceData, clients)
+	require.Nil(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, testBranchLockRef, imported[0].Get("ref"))
+	require.Equal(t, testBranchLockRepoID+":"+testBranchLockRef, imported[0].Id())
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/serviceendpoint"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
@@ -39,12 +40,17 @@ var RepoInitTypeValues = repoInitTypeValuesType{
 
 // ResourceGitRepository schema and implementation for git repo resource
 func ResourceGitRepository() *schema.Resource {
+	passwordHashKey, passwordHashSchema := tfhelper.GenerateSecreteMemoSchema("password")
 	return &schema.Resource{
 		Create:   resourceGitRepositoryCreate,
 		Read:     resourceGitRepositoryRead,
 		Update:   resourceGitRepositoryUpdate,
 		Delete:   resourceGitRepositoryDelete,
 		Importer: tfhelper.ImportProjectQualifiedResource(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:             schema.TypeString,
@@ -96,6 +102,45 @@ func ResourceGitRepository() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"is_disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"allow_forks": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"fork_sync": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				RequiredWith: []string{"parent_repository_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_branch": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "refs/heads/master",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"target_branch": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"trigger": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"fork_sync_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"initialization": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -114,20 +159,52 @@ func ResourceGitRepository() *schema.Resource {
 							}, false),
 						},
 						"source_type": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ForceNew:     true,
-							ValidateFunc: validation.StringInSlice([]string{"Git"}, false),
-							RequiredWith: []string{"initialization.0.source_url"},
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: suppressExistingGitRepositoryInitialization,
+							ValidateFunc:     validation.StringInSlice([]string{"Git"}, false),
+							RequiredWith:     []string{"initialization.0.source_url"},
 						},
 						"source_url": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: suppressExistingGitRepositoryInitialization,
+							Default:          "",
+							RequiredWith:     []string{"initialization.0.source_type"},
+							ValidateFunc:     validation.IsURLWithHTTPorHTTPS,
+						},
+						"service_connection_id": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: suppressExistingGitRepositoryInitialization,
+							ValidateFunc:     validation.IsUUID,
+							ConflictsWith:    []string{"initialization.0.username", "initialization.0.password"},
+						},
+						"username": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: suppressExistingGitRepositoryInitialization,
+							ValidateFunc:     validation.StringIsNotWhiteSpace,
+							RequiredWith:     []string{"initialization.0.password"},
+							ConflictsWith:    []string{"initialization.0.service_connection_id"},
+						},
+						"password": {
 							Type:         schema.TypeString,
 							Optional:     true,
 							ForceNew:     true,
-							Default:      "",
-							RequiredWith: []string{"initialization.0.source_type"},
-							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return suppressExistingGitRepositoryInitialization(k, old, new, d) || tfhelper.DiffFuncSuppressSecretChanged(k, old, new, d)
+							},
+							RequiredWith:  []string{"initialization.0.username"},
+							ConflictsWith: []string{"initialization.0.service_connection_id"},
 						},
+						passwordHashKey: passwordHashSchema,
 					},
 				},
 			},
@@ -135,11 +212,21 @@ func ResourceGitRepository() *schema.Resource {
 	}
 }
 
+// suppressExistingGitRepositoryInitialization suppresses the replacement of an existing repository whose state does
+// not contain the initialization, e.g. after an import, because the initialization is only used to create a repository
+// and cannot be read from the service.
+func suppressExistingGitRepositoryInitialization(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == ""
+}
+
 // A helper type that is used for transient info only used during repo creation
 type repoInitializationMeta struct {
-	initType   string
-	sourceType string
-	sourceURL  string
+	initType            string
+	sourceType          string
+	sourceURL           string
+	serviceConnectionID string
+	username            string
+	password            string
 }
 
 func resourceGitRepositoryCreate(d *schema.ResourceData, m interface{}) error {
@@ -169,16 +256,11 @@ func resourceGitRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 
 	if initialization != nil && strings.EqualFold(initialization.initType, string(RepoInitTypeValues.Import)) &&
 		strings.EqualFold(initialization.sourceType, "Git") {
-		importRequest := git.GitImportRequest{
-			Parameters: &git.GitImportRequestParameters{
-				GitSource: &git.GitImportGitSource{
-					Url: &initialization.sourceURL,
-				},
-			},
-			Repository: createdRepo,
-		}
-		_, importErr := createImportRequest(clients, importRequest, projectID.String(), *createdRepo.Name)
+		importErr := importGitRepository(clients, createdRepo, projectID.String(), initialization, d.Timeout(schema.TimeoutCreate))
 		if importErr != nil {
+			if err := deleteGitRepository(clients, createdRepo.Id.String()); err != nil {
+				log.Printf("[WARN] Unable to delete new Git Repository after import failed: %+v", err)
+			}
 			return fmt.Errorf("Error import repository in Azure DevOps: %+v ", importErr)
 		}
 	}
@@ -200,9 +282,43 @@ func resourceGitRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(createdRepo.Id.String())
+	flattenGitRepositoryInitialization(d)
+
+	if forkSync := expandGitRepositoryForkSync(d); forkSync != nil {
+		status, err := syncGitRepositoryFork(clients, createdRepo.Id.String(), projectID.String(), d.Get("parent_repository_id").(string), forkSync, d.Timeout(schema.TimeoutCreate))
+		d.Set("fork_sync_status", status)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !d.Get("allow_forks").(bool) {
+		err = updateGitRepositoryAllowForks(clients, projectID.String(), createdRepo.Id.String(), false)
+		if err != nil {
+			return fmt.Errorf("Error updating fork settings of repository in Azure DevOps: %+v", err)
+		}
+	}
+	if d.Get("is_disabled").(bool) {
+		err = updateGitRepositoryIsDisabled(clients, projectID.String(), createdRepo.Id.String(), true)
+		if err != nil {
+			return fmt.Errorf("Error disabling repository in Azure DevOps: %+v", err)
+		}
+	}
 	return resourceGitRepositoryRead(d, m)
 }
 
+// flattenGitRepositoryInitialization stores the hash of the password used to import a repository
+func flattenGitRepositoryInitialization(d *schema.ResourceData) {
+	initData := d.Get("initialization").([]interface{})
+	if len(initData) != 1 || initData[0] == nil {
+		return
+	}
+	initValues := initData[0].(map[string]interface{})
+	newHash, hashKey := tfhelper.HelpFlattenSecretNested(d, "initialization", initValues, "password")
+	initValues[hashKey] = newHash
+	d.Set("initialization", []interface{}{initValues})
+}
+
 func waitForBranch(clients *client.AggregatedClient, repoName *string, projectID fmt.Stringer) error {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"Waiting"},
@@ -231,6 +347,70 @@ func waitForBranch(clients *client.AggregatedClient, repoName *string, projectID
 	return nil
 }
 
+// importGitRepository imports the content of a remote Git repository into the new repository and waits
+// until the import has finished. Credentials are passed to the service either by an existing service
+// connection or by a temporary service connection which the service deletes after the import.
+func importGitRepository(clients *client.AggregatedClient, repo *git.GitRepository, projectID string, initialization *repoInitializationMeta, timeout time.Duration) error {
+	parameters := &git.GitImportRequestParameters{
+		GitSource: &git.GitImportGitSource{
+			Url: &initialization.sourceURL,
+		},
+	}
+
+	if initialization.serviceConnectionID != "" {
+		serviceConnectionID, err := uuid.Parse(initialization.serviceConnectionID)
+		if err != nil {
+			return fmt.Errorf("Invalid service connection ID %s: %+v", initialization.serviceConnectionID, err)
+		}
+		parameters.ServiceEndpointId = &serviceConnectionID
+	} else if initialization.username != "" {
+		serviceEndpoint, err := createImportServiceEndpoint(clients, *repo.Name, projectID, initialization)
+		if err != nil {
+			return fmt.Errorf("Error creating service connection for the import: %+v", err)
+		}
+		parameters.ServiceEndpointId = serviceEndpoint.Id
+		parameters.DeleteServiceEndpointAfterImportIsDone = converter.Bool(true)
+	}
+
+	importRequest, err := createImportRequest(clients, git.GitImportRequest{
+		Parameters: parameters,
+		Repository: repo,
+	}, projectID, *repo.Name)
+	if err != nil {
+		// the service only deletes the temporary service connection once an import request exists
+		if parameters.DeleteServiceEndpointAfterImportIsDone != nil {
+			deleteErr := clients.ServiceEndpointClient.DeleteServiceEndpoint(clients.Ctx, serviceendpoint.DeleteServiceEndpointArgs{
+				Project:    converter.String(projectID),
+				EndpointId: parameters.ServiceEndpointId,
+			})
+			if deleteErr != nil {
+				log.Printf("[WARN] Unable to delete service connection %s after the import request failed: %+v", parameters.ServiceEndpointId.String(), deleteErr)
+			}
+		}
+		return err
+	}
+	return waitForImportRequest(clients, projectID, repo.Id.String(), importRequest.ImportRequestId, timeout)
+}
+
+func createImportServiceEndpoint(clients *client.AggregatedClient, repoName string, projectID string, initialization *repoInitializationMeta) (*serviceendpoint.ServiceEndpoint, error) {
+	return clients.ServiceEndpointClient.CreateServiceEndpoint(clients.Ctx, serviceendpoint.CreateServiceEndpointArgs{
+		Endpoint: &serviceendpoint.ServiceEndpoint{
+			Name:  converter.String(fmt.Sprintf("%s-import-%s", repoName, uuid.New().String())),
+			Type:  converter.String("git"),
+			Url:   converter.String(initialization.sourceURL),
+			Owner: converter.String("library"),
+			Authorization: &serviceendpoint.EndpointAuthorization{
+				Scheme: converter.String("UsernamePassword"),
+				Parameters: &map[string]string{
+					"username": initialization.username,
+					"password": initialization.password,
+				},
+			},
+		},
+		Project: converter.String(projectID),
+	})
+}
+
 func createImportRequest(clients *client.AggregatedClient, gitImportRequest git.GitImportRequest, project string, repositoryID string) (*git.GitImportRequest, error) {
 	args := git.CreateImportRequestArgs{
 		ImportRequest: &gitImportRequest,
@@ -241,6 +421,32 @@ func createImportRequest(clients *client.AggregatedClient, gitImportRequest git.
 	return clients.GitReposClient.CreateImportRequest(clients.Ctx, args)
 }
 
+func waitForImportRequest(clients *client.AggregatedClient, projectID string, repositoryID string, importRequestID *int, timeout time.Duration) error {
+	if importRequestID == nil {
+		return fmt.Errorf("The service did not return the ID of the import request")
+	}
+
+	err := waitForGitAsyncOperation(timeout, func() (*git.GitAsyncOperationStatus, string, error) {
+		importRequest, err := clients.GitReposClient.GetImportRequest(clients.Ctx, git.GetImportRequestArgs{
+			Project:         converter.String(projectID),
+			RepositoryId:    converter.String(repositoryID),
+			ImportRequestId: importRequestID,
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("Error reading import request: %+v", err)
+		}
+		errorMessage := ""
+		if importRequest.DetailedStatus != nil {
+			errorMessage = converter.ToString(importRequest.DetailedStatus.ErrorMessage, "")
+		}
+		return importRequest.Status, errorMessage, nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for import request %d of repository %s: %+v", *importRequestID, repositoryID, err)
+	}
+	return nil
+}
+
 func createGitRepository(clients *client.AggregatedClient, repoName *string, projectID *uuid.UUID, parentRepo *git.GitRepositoryRef) (*git.GitRepository, error) {
 	args := git.CreateRepositoryArgs{
 		GitRepositoryToCreate: &git.GitRepositoryCreateOptions{
@@ -305,19 +511,40 @@ func resourceGitRepositoryRead(d *schema.ResourceData, m interface{}) error {
 	projectID := d.Get("project_id").(string)
 
 	clients := m.(*client.AggregatedClient)
+	isDisabled := false
 	repo, err := gitRepositoryRead(clients, repoID, repoName, projectID)
 	if err != nil {
-		if utils.ResponseWasNotFound(err) {
+		if !utils.ResponseWasNotFound(err) {
+			return fmt.Errorf("Error looking up repository with ID %s and Name %s. Error: %v", repoID, repoName, err)
+		}
+
+		// disabled repositories cannot be read directly
+		repo, err = findDisabledGitRepository(clients, repoID, repoName, projectID)
+		if err != nil {
+			return fmt.Errorf("Error looking up disabled repository with ID %s and Name %s. Error: %v", repoID, repoName, err)
+		}
+		if repo == nil {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error looking up repository with ID %s and Name %s. Error: %v", repoID, repoName, err)
+		isDisabled = true
 	}
 
 	err = flattenGitRepository(d, repo)
 	if err != nil {
 		return fmt.Errorf("Failed to flatten Git repository: %w", err)
 	}
+	d.Set("is_disabled", isDisabled)
+
+	// Reading the fork settings requires listing the settings policies of the project. They are only read
+	// if forks are not allowed by the state, which includes an import, otherwise the default is kept.
+	if !d.Get("allow_forks").(bool) {
+		allowForks, err := readGitRepositoryAllowForks(clients, repo.Project.Id.String(), repo.Id.String())
+		if err != nil {
+			return fmt.Errorf("Error reading fork settings of repository %s: %+v", repo.Id.String(), err)
+		}
+		d.Set("allow_forks", allowForks)
+	}
 	return nil
 }
 
@@ -328,14 +555,53 @@ func resourceGitRepositoryUpdate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error converting terraform data model to AzDO project reference: %+v", err)
 	}
 
-	_, err = updateGitRepository(clients, repo, projectID)
+	// a disabled repository cannot be modified, so it is enabled before and disabled again after all other changes
+	wasDisabled, isDisabled := d.GetChange("is_disabled")
+	if wasDisabled.(bool) {
+		err = updateGitRepositoryIsDisabled(clients, projectID.String(), repo.Id.String(), false)
+		if err != nil {
+			return fmt.Errorf("Error enabling repository in Azure DevOps: %+v", err)
+		}
+	}
+
+	err = updateGitRepositoryProperties(d, clients, repo, projectID)
+
+	if isDisabled.(bool) {
+		if disableErr := updateGitRepositoryIsDisabled(clients, projectID.String(), repo.Id.String(), true); disableErr != nil && err == nil {
+			err = fmt.Errorf("Error disabling repository in Azure DevOps: %+v", disableErr)
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("Error updating repository in Azure DevOps: %+v", err)
+		return err
 	}
 
 	return resourceGitRepositoryRead(d, m)
 }
 
+// updateGitRepositoryProperties applies all changes except the disabled state to an enabled repository
+func updateGitRepositoryProperties(d *schema.ResourceData, clients *client.AggregatedClient, repo *git.GitRepository, projectID *uuid.UUID) error {
+	_, err := updateGitRepository(clients, repo, projectID)
+	if err != nil {
+		return fmt.Errorf("Error updating repository in Azure DevOps: %+v", err)
+	}
+
+	if forkSync := expandGitRepositoryForkSync(d); forkSync != nil && d.HasChange("fork_sync") {
+		status, err := syncGitRepositoryFork(clients, repo.Id.String(), projectID.String(), d.Get("parent_repository_id").(string), forkSync, d.Timeout(schema.TimeoutUpdate))
+		d.Set("fork_sync_status", status)
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("allow_forks") {
+		err = updateGitRepositoryAllowForks(clients, projectID.String(), repo.Id.String(), d.Get("allow_forks").(bool))
+		if err != nil {
+			return fmt.Errorf("Error updating fork settings of repository in Azure DevOps: %+v", err)
+		}
+	}
+	return nil
+}
+
 func updateGitRepository(clients *client.AggregatedClient, repository *git.GitRepository, project fmt.Stringer) (*git.GitRepository, error) {
 	if nil == project {
 		return nil, fmt.Errorf("updateGitRepository: ID of project cannot be nil")
@@ -436,14 +702,20 @@ func expandGitRepository(d *schema.ResourceData) (*git.GitRepository, *repoIniti
 		initValues := initData[0].(map[string]interface{})
 
 		initialization = &repoInitializationMeta{
-			initType:   initValues["init_type"].(string),
-			sourceType: initValues["source_type"].(string),
-			sourceURL:  initValues["source_url"].(string),
+			initType:            initValues["init_type"].(string),
+			sourceType:          initValues["source_type"].(string),
+			sourceURL:           initValues["source_url"].(string),
+			serviceConnectionID: initValues["service_connection_id"].(string),
+			username:            initValues["username"].(string),
+			password:            initValues["password"].(string),
 		}
 
 		if strings.EqualFold(initialization.initType, "clean") {
 			initialization.sourceType = ""
 			initialization.sourceURL = ""
+			initialization.serviceConnectionID = ""
+			initialization.username = ""
+			initialization.password = ""
 		}
 	} else if len(initData) > 1 {
 		return nil, nil, nil, fmt.Errorf("Multiple initialization blocks")
@@ -0,0 +1,166 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+)
+
+// ResourceGitRepositoryBranch schema and implementation for branches of a git repository
+func ResourceGitRepositoryBranch() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitRepositoryBranchCreate,
+		Read:   resourceGitRepositoryBranchRead,
+		Delete: resourceGitRepositoryBranchDelete,
+		Importer: &schema.ResourceImporter{
+			State: importGitRepositoryBranch,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"repository_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.IsUUID,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsNotWhiteSpace,
+				DiffSuppressFunc: suppressHeadsPrefixDifference,
+			},
+			"ref_branch": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsNotWhiteSpace,
+				DiffSuppressFunc: suppressSourceRefDiffAfterImport,
+				ExactlyOneOf:     []string{"ref_branch", "ref_tag", "ref_commit_id"},
+			},
+			"ref_tag": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsNotWhiteSpace,
+				DiffSuppressFunc: suppressSourceRefDiffAfterImport,
+				ExactlyOneOf:     []string{"ref_branch", "ref_tag", "ref_commit_id"},
+			},
+			"ref_commit_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexpCommitID, "Commit ID must be a full SHA-1 hash"),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return suppress.CaseDifference(k, old, new, d) || suppressSourceRefDiffAfterImport(k, old, new, d)
+				},
+				ExactlyOneOf: []string{"ref_branch", "ref_tag", "ref_commit_id"},
+			},
+			"ref": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_commit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGitRepositoryBranchCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	refName := withHeadsPrefix(d.Get("name").(string))
+
+	commitID, err := resolveGitCommitID(clients, projectID, repositoryID,
+		d.Get("ref_branch").(string),
+		d.Get("ref_tag").(string),
+		d.Get("ref_commit_id").(string))
+	if err != nil {
+		return err
+	}
+
+	err = createGitRef(clients, projectID, repositoryID, refName, commitID)
+	if err != nil {
+		return fmt.Errorf("Error creating branch %s in repository %s: %+v", refName, repositoryID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", repositoryID, refName))
+	return resourceGitRepositoryBranchRead(d, m)
+}
+
+func resourceGitRepositoryBranchRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	refName := withHeadsPrefix(d.Get("name").(string))
+
+	ref, err := getGitRef(clients, projectID, repositoryID, refName)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading branch %s in repository %s: %+v", refName, repositoryID, err)
+	}
+	if ref == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("ref", ref.Name)
+	d.Set("last_commit_id", ref.ObjectId)
+	return nil
+}
+
+func resourceGitRepositoryBranchDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	refName := withHeadsPrefix(d.Get("name").(string))
+
+	// the current commit is required to delete a ref, the branch might have moved since the last refresh
+	ref, err := getGitRef(clients, projectID, repositoryID, refName)
+	if err != nil {
+		return fmt.Errorf("Error reading branch %s in repository %s: %+v", refName, repositoryID, err)
+	}
+	if ref == nil {
+		d.SetId("")
+		return nil
+	}
+
+	err = deleteGitRef(clients, projectID, repositoryID, refName, ref.ObjectId)
+	if err != nil {
+		return fmt.Errorf("Error deleting branch %s in repository %s: %+v", refName, repositoryID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func importGitRepositoryBranch(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	projectID, repositoryID, branchName, err := parseGitRefImportID(d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("repository_id", repositoryID)
+	d.Set("name", strings.TrimPrefix(branchName, headsPrefix))
+	d.SetId(fmt.Sprintf("%s:%s", repositoryID, withHeadsPrefix(branchName)))
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,215 @@
+// +build all git resource_git_repository_branch
+// +build !exclude_git !exclude_resource_git_repository_branch
+
+package git
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testBranchProjectID = uuid.New().String()
+var testBranchRepoID = uuid.New().String()
+
+const testBranchCommitID = "0123456789abcdef0123456789abcdef01234567"
+
+func getRepositoryBranchResourceData(t *testing.T) *schema.ResourceData {
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepositoryBranch().Schema, nil)
+	resourceData.Set("project_id", testBranchProjectID)
+	resourceData.Set("repository_id", testBranchRepoID)
+	resourceData.Set("name", "release/1.0")
+	return resourceData
+}
+
+// verifies that a branch created from a tag points to the commit of the peeled tag
+func TestGitRepositoryBranch_Create_FromAnnotatedTagUsesPeeledCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryBranchResourceData(t)
+	resourceData.Set("ref_tag", "v1.0")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, git.GetRefsArgs{
+			RepositoryId: converter.String(testBranchRepoID),
+			Project:      converter.String(testBranchProjectID),
+			Filter:       converter.String("tags/v1.0"),
+			PeelTags:     converter.Bool(true),
+		}).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{
+					Name:           converter.String("refs/tags/v1.0"),
+					ObjectId:       converter.String("fedcba9876543210fedcba9876543210fedcba98"),
+					PeeledObjectId: converter.String(testBranchCommitID),
+				},
+			},
+		}, nil).
+		Times(1)
+
+	expectedArgs := git.UpdateRefsArgs{
+		RefUpdates: &[]git.GitRefUpdate{
+			{
+				Name:        converter.String("refs/heads/release/1.0"),
+				OldObjectId: converter.String(emptyObjectID),
+				NewObjectId: converter.String(testBranchCommitID),
+			},
+		},
+		RepositoryId: converter.String(testBranchRepoID),
+		Project:      converter.String(testBranchProjectID),
+	}
+	reposClient.
+		EXPECT().
+		UpdateRefs(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateRefs() Failed")).
+		Times(1)
+
+	err := resourceGitRepositoryBranchCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "UpdateRefs() Failed")
+}
+
+// verifies that a rejected ref update is reported as an error
+func TestGitRepositoryBranch_Create_DoesNotSwallowRejectedRefUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryBranchResourceData(t)
+	resourceData.Set("ref_commit_id", testBranchCommitID)
+
+	status := git.GitRefUpdateStatusValues.CreateBranchPermissionRequired
+	reposClient.
+		EXPECT().
+		UpdateRefs(clients.Ctx, gomock.Any()).
+		Return(&[]git.GitRefUpdateResult{
+			{
+				Name:         converter.String("refs/heads/release/1.0"),
+				Success:      converter.Bool(false),
+				UpdateStatus: &status,
+			},
+		}, nil).
+		Times(1)
+
+	err := resourceGitRepositoryBranchCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), string(status))
+}
+
+// verifies that a branch which has been deleted outside of Terraform is removed from the state
+func TestGitRepositoryBranch_Read_RemovesDeletedBranchFromState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryBranchResourceData(t)
+	resourceData.SetId(testBranchRepoID + ":refs/heads/release/1.0")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{Name: converter.String("refs/heads/release/1.0.1"), ObjectId: converter.String(testBranchCommitID)},
+			},
+		}, nil).
+		Times(1)
+
+	err := resourceGitRepositoryBranchRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that the delete operation removes the ref based on its current commit
+func TestGitRepositoryBranch_Delete_UsesCurrentCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryBranchResourceData(t)
+	resourceData.SetId(testBranchRepoID + ":refs/heads/release/1.0")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{Name: converter.String("refs/heads/release/1.0"), ObjectId: converter.String(testBranchCommitID)},
+			},
+		}, nil).
+		Times(1)
+
+	expectedArgs := git.UpdateRefsArgs{
+		RefUpdates: &[]git.GitRefUpdate{
+			{
+				Name:        converter.String("refs/heads/release/1.0"),
+				OldObjectId: converter.String(testBranchCommitID),
+				NewObjectId: converter.String(emptyObjectID),
+			},
+		},
+		RepositoryId: converter.String(testBranchRepoID),
+		Project:      converter.String(testBranchProjectID),
+	}
+	reposClient.
+		EXPECT().
+		UpdateRefs(clients.Ctx, expectedArgs).
+		Return(&[]git.GitRefUpdateResult{{Success: converter.Bool(true)}}, nil).
+		Times(1)
+
+	err := resourceGitRepositoryBranchDelete(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that an imported branch is not replaced if the configured name contains the refs/heads/ prefix
+func TestGitRepositoryBranch_Diff_IgnoresHeadsPrefixOfName(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: testBranchRepoID + ":refs/heads/release/1.0",
+		Attributes: map[string]string{
+			"project_id":    testBranchProjectID,
+			"repository_id": testBranchRepoID,
+			"name":          "release/1.0",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":    testBranchProjectID,
+		"repository_id": testBranchRepoID,
+		"name":          "refs/heads/release/1.0",
+		"ref_branch":    "master",
+	})
+
+	diff, err := ResourceGitRepositoryBranch().Diff(state, config, nil)
+	require.Nil(t, err)
+	require.Nil(t, diff)
+
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":    testBranchProjectID,
+		"repository_id": testBranchRepoID,
+		"name":          "refs/heads/release/2.0",
+		"ref_branch":    "master",
+	})
+	diff, err = ResourceGitRepositoryBranch().Diff(state, config, nil)
+	require.Nil(t, err)
+	require.True(t, diff.RequiresNew())
+}
@@ -0,0 +1,295 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+)
+
+// Pushes to the same branch have to be serialized, otherwise all but the first
+// push of a Terraform run fail because the branch has moved.
+var gitRepositoryFilePushLock sync.Mutex
+
+// ResourceGitRepositoryFile schema and implementation for files committed to a git repository
+func ResourceGitRepositoryFile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitRepositoryFileCreate,
+		Read:   resourceGitRepositoryFileRead,
+		Update: resourceGitRepositoryFileUpdate,
+		Delete: resourceGitRepositoryFileDelete,
+		Importer: &schema.ResourceImporter{
+			State: importGitRepositoryFile,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"repository_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.IsUUID,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"file": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"branch": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "refs/heads/master",
+				ValidateFunc: validation.StringMatch(regexpBranchRef, "Branch must be a fully qualified branch name starting with refs/heads/"),
+			},
+			"commit_message": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"author_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				RequiredWith: []string{"author_email"},
+			},
+			"author_email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				RequiredWith: []string{"author_name"},
+			},
+			"overwrite_on_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"object_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGitRepositoryFileCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	file := d.Get("file").(string)
+	branch := d.Get("branch").(string)
+
+	existingItem, err := getGitRepositoryFileItem(clients, projectID, repositoryID, file, branch)
+	if err != nil && !utils.ResponseWasNotFound(err) {
+		return fmt.Errorf("Error checking for existing file %s in repository %s: %+v", file, repositoryID, err)
+	}
+
+	changeType := git.VersionControlChangeTypeValues.Add
+	if existingItem != nil {
+		if !d.Get("overwrite_on_create").(bool) {
+			return fmt.Errorf("File %s already exists in branch %s of repository %s, set overwrite_on_create to true to overwrite it", file, branch, repositoryID)
+		}
+		changeType = git.VersionControlChangeTypeValues.Edit
+	}
+
+	err = pushGitRepositoryFileChange(clients, d, changeType)
+	if err != nil {
+		return fmt.Errorf("Error creating file %s in repository %s: %+v", file, repositoryID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s:%s", repositoryID, file, branch))
+	return resourceGitRepositoryFileRead(d, m)
+}
+
+func resourceGitRepositoryFileRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	file := d.Get("file").(string)
+	branch := d.Get("branch").(string)
+
+	item, err := getGitRepositoryFileItem(clients, projectID, repositoryID, file, branch)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading file %s in repository %s: %+v", file, repositoryID, err)
+	}
+
+	d.Set("content", item.Content)
+	d.Set("object_id", item.ObjectId)
+	return nil
+}
+
+func resourceGitRepositoryFileUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	file := d.Get("file").(string)
+	repositoryID := d.Get("repository_id").(string)
+
+	if d.HasChange("content") {
+		err := pushGitRepositoryFileChange(clients, d, git.VersionControlChangeTypeValues.Edit)
+		if err != nil {
+			return fmt.Errorf("Error updating file %s in repository %s: %+v", file, repositoryID, err)
+		}
+	}
+	return resourceGitRepositoryFileRead(d, m)
+}
+
+func resourceGitRepositoryFileDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	file := d.Get("file").(string)
+	branch := d.Get("branch").(string)
+
+	// a file which has already been removed outside of Terraform does not need to be deleted
+	_, err := getGitRepositoryFileItem(clients, projectID, repositoryID, file, branch)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading file %s in repository %s: %+v", file, repositoryID, err)
+	}
+
+	err = pushGitRepositoryFileChange(clients, d, git.VersionControlChangeTypeValues.Delete)
+	if err != nil && !utils.ResponseWasNotFound(err) {
+		return fmt.Errorf("Error deleting file %s in repository %s: %+v", file, repositoryID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// pushGitRepositoryFileChange commits a single change of the file on top of the current tip of the branch
+func pushGitRepositoryFileChange(clients *client.AggregatedClient, d *schema.ResourceData, changeType git.VersionControlChangeType) error {
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	file := d.Get("file").(string)
+	branch := d.Get("branch").(string)
+
+	gitRepositoryFilePushLock.Lock()
+	defer gitRepositoryFilePushLock.Unlock()
+
+	branchRef, err := getGitRef(clients, projectID, repositoryID, branch)
+	if err != nil {
+		return fmt.Errorf("Error reading branch %s: %+v", branch, err)
+	}
+	if branchRef == nil {
+		return fmt.Errorf("Branch %s does not exist", branch)
+	}
+
+	change := git.Change{
+		ChangeType: &changeType,
+		Item: git.GitItem{
+			Path: converter.String(file),
+		},
+	}
+	if changeType != git.VersionControlChangeTypeValues.Delete {
+		change.NewContent = &git.ItemContent{
+			ContentType: &git.ItemContentTypeValues.RawText,
+			Content:     converter.String(d.Get("content").(string)),
+		}
+	}
+
+	commit := git.GitCommitRef{
+		Comment: converter.String(gitRepositoryFileCommitMessage(d, changeType)),
+		Changes: &[]interface{}{change},
+	}
+	if authorName, ok := d.GetOk("author_name"); ok {
+		commit.Author = &git.GitUserDate{
+			Name:  converter.String(authorName.(string)),
+			Email: converter.String(d.Get("author_email").(string)),
+		}
+	}
+
+	_, err = clients.GitReposClient.CreatePush(clients.Ctx, git.CreatePushArgs{
+		RepositoryId: converter.String(repositoryID),
+		Project:      converter.String(projectID),
+		Push: &git.GitPush{
+			RefUpdates: &[]git.GitRefUpdate{
+				{
+					Name:        converter.String(branch),
+					OldObjectId: branchRef.ObjectId,
+				},
+			},
+			Commits: &[]git.GitCommitRef{commit},
+		},
+	})
+	return err
+}
+
+func gitRepositoryFileCommitMessage(d *schema.ResourceData, changeType git.VersionControlChangeType) string {
+	if message, ok := d.GetOk("commit_message"); ok {
+		return message.(string)
+	}
+
+	file := d.Get("file").(string)
+	switch changeType {
+	case git.VersionControlChangeTypeValues.Add:
+		return fmt.Sprintf("Add %s", file)
+	case git.VersionControlChangeTypeValues.Delete:
+		return fmt.Sprintf("Delete %s", file)
+	default:
+		return fmt.Sprintf("Update %s", file)
+	}
+}
+
+// getGitRepositoryFileItem reads the blob of the file at the tip of the branch
+func getGitRepositoryFileItem(clients *client.AggregatedClient, projectID string, repositoryID string, file string, branch string) (*git.GitItem, error) {
+	return clients.GitReposClient.GetItem(clients.Ctx, git.GetItemArgs{
+		RepositoryId:   converter.String(repositoryID),
+		Project:        converter.String(projectID),
+		Path:           converter.String(file),
+		IncludeContent: converter.Bool(true),
+		VersionDescriptor: &git.GitVersionDescriptor{
+			Version:     converter.String(strings.TrimPrefix(branch, headsPrefix)),
+			VersionType: &git.GitVersionTypeValues.Branch,
+		},
+	})
+}
+
+// importGitRepositoryFile imports a file by an ID that looks like one of the following:
+//		<project ID>/<repository ID>/<file path>:<branch>
+//		<project name>/<repository name>/<file path>
+// The default branch refs/heads/master is used if the branch is omitted.
+func importGitRepositoryFile(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	projectID, repositoryID, fileAndBranch, err := parseGitRefImportID(d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+
+	file := fileAndBranch
+	branch := "refs/heads/master"
+	if i := strings.LastIndex(fileAndBranch, ":"); i >= 0 {
+		file = fileAndBranch[:i]
+		branch = withHeadsPrefix(fileAndBranch[i+1:])
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("repository_id", repositoryID)
+	d.Set("file", file)
+	d.Set("branch", branch)
+	d.Set("overwrite_on_create", false)
+	d.SetId(fmt.Sprintf("%s/%s:%s", repositoryID, file, branch))
+	return []*schema.ResourceData{d}, nil
+}
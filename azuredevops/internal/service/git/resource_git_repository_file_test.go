@@ -0,0 +1,283 @@
+// +build all git resource_git_repository_file
+// +build !exclude_git !exclude_resource_git_repository_file
+
+package git
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testFileProjectID = uuid.New().String()
+var testFileRepoID = uuid.New().String()
+
+const testFileBranchCommitID = "0123456789abcdef0123456789abcdef01234567"
+
+func getRepositoryFileResourceData(t *testing.T) *schema.ResourceData {
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepositoryFile().Schema, nil)
+	resourceData.Set("project_id", testFileProjectID)
+	resourceData.Set("repository_id", testFileRepoID)
+	resourceData.Set("file", "azure-pipelines.yml")
+	resourceData.Set("content", "trigger: none")
+	resourceData.Set("branch", "refs/heads/master")
+	return resourceData
+}
+
+func expectBranchTip(reposClient *azdosdkmocks.MockGitClient, ctx context.Context) {
+	reposClient.
+		EXPECT().
+		GetRefs(ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{Name: converter.String("refs/heads/master"), ObjectId: converter.String(testFileBranchCommitID)},
+			},
+		}, nil).
+		Times(1)
+}
+
+func expectExistingFile(reposClient *azdosdkmocks.MockGitClient, ctx context.Context) {
+	reposClient.
+		EXPECT().
+		GetItem(ctx, gomock.Any()).
+		Return(&git.GitItem{Path: converter.String("/azure-pipelines.yml")}, nil).
+		Times(1)
+}
+
+// verifies that an existing file is not overwritten unless overwrite_on_create is set
+func TestGitRepositoryFile_Create_FailsIfFileExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryFileResourceData(t)
+
+	reposClient.
+		EXPECT().
+		GetItem(clients.Ctx, gomock.Any()).
+		Return(&git.GitItem{Path: converter.String("/azure-pipelines.yml")}, nil).
+		Times(1)
+	reposClient.
+		EXPECT().
+		CreatePush(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := resourceGitRepositoryFileCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "overwrite_on_create")
+}
+
+// verifies that an existing file is edited if overwrite_on_create is set
+func TestGitRepositoryFile_Create_OverwritesExistingFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryFileResourceData(t)
+	resourceData.Set("overwrite_on_create", true)
+	resourceData.Set("commit_message", "Bootstrap pipeline")
+	resourceData.Set("author_name", "Build Bot")
+	resourceData.Set("author_email", "bot@example.com")
+
+	reposClient.
+		EXPECT().
+		GetItem(clients.Ctx, gomock.Any()).
+		Return(&git.GitItem{Path: converter.String("/azure-pipelines.yml")}, nil).
+		Times(1)
+	expectBranchTip(reposClient, clients.Ctx)
+
+	reposClient.
+		EXPECT().
+		CreatePush(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args git.CreatePushArgs) (*git.GitPush, error) {
+			require.Equal(t, testFileBranchCommitID, *(*args.Push.RefUpdates)[0].OldObjectId)
+			commit := (*args.Push.Commits)[0]
+			require.Equal(t, "Bootstrap pipeline", *commit.Comment)
+			require.Equal(t, "Build Bot", *commit.Author.Name)
+			require.Equal(t, "bot@example.com", *commit.Author.Email)
+			change := (*commit.Changes)[0].(git.Change)
+			require.Equal(t, git.VersionControlChangeTypeValues.Edit, *change.ChangeType)
+			require.Equal(t, "trigger: none", *change.NewContent.Content)
+			return nil, errors.New("CreatePush() Failed")
+		}).
+		Times(1)
+
+	err := resourceGitRepositoryFileCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "CreatePush() Failed")
+}
+
+// verifies that the content of the file at the tip of the branch is stored in the state
+func TestGitRepositoryFile_Read_DetectsDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryFileResourceData(t)
+	resourceData.SetId("an-id")
+
+	expectedArgs := git.GetItemArgs{
+		RepositoryId:   converter.String(testFileRepoID),
+		Project:        converter.String(testFileProjectID),
+		Path:           converter.String("azure-pipelines.yml"),
+		IncludeContent: converter.Bool(true),
+		VersionDescriptor: &git.GitVersionDescriptor{
+			Version:     converter.String("master"),
+			VersionType: &git.GitVersionTypeValues.Branch,
+		},
+	}
+	reposClient.
+		EXPECT().
+		GetItem(clients.Ctx, expectedArgs).
+		Return(&git.GitItem{
+			Content:  converter.String("trigger: [master]"),
+			ObjectId: converter.String("fedcba9876543210fedcba9876543210fedcba98"),
+		}, nil).
+		Times(1)
+
+	err := resourceGitRepositoryFileRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "trigger: [master]", resourceData.Get("content"))
+	require.Equal(t, "fedcba9876543210fedcba9876543210fedcba98", resourceData.Get("object_id"))
+}
+
+// verifies that a file which has been removed outside of Terraform is removed from the state
+func TestGitRepositoryFile_Read_RemovesDeletedFileFromState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryFileResourceData(t)
+	resourceData.SetId("an-id")
+
+	reposClient.
+		EXPECT().
+		GetItem(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	err := resourceGitRepositoryFileRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that the delete operation pushes a commit deleting the file
+func TestGitRepositoryFile_Delete_PushesDeleteChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryFileResourceData(t)
+	resourceData.SetId("an-id")
+
+	expectExistingFile(reposClient, clients.Ctx)
+	expectBranchTip(reposClient, clients.Ctx)
+	reposClient.
+		EXPECT().
+		CreatePush(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args git.CreatePushArgs) (*git.GitPush, error) {
+			commit := (*args.Push.Commits)[0]
+			require.Equal(t, "Delete azure-pipelines.yml", *commit.Comment)
+			change := (*commit.Changes)[0].(git.Change)
+			require.Equal(t, git.VersionControlChangeTypeValues.Delete, *change.ChangeType)
+			require.Nil(t, change.NewContent)
+			return &git.GitPush{}, nil
+		}).
+		Times(1)
+
+	err := resourceGitRepositoryFileDelete(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that a file which has already been removed outside of Terraform is not deleted again
+func TestGitRepositoryFile_Delete_IgnoresDeletedFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryFileResourceData(t)
+	resourceData.SetId("an-id")
+
+	reposClient.
+		EXPECT().
+		GetItem(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	err := resourceGitRepositoryFileDelete(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that a file which is removed while the delete change is pushed is considered deleted
+func TestGitRepositoryFile_Delete_IgnoresNotFoundPush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryFileResourceData(t)
+	resourceData.SetId("an-id")
+
+	expectExistingFile(reposClient, clients.Ctx)
+	expectBranchTip(reposClient, clients.Ctx)
+	reposClient.
+		EXPECT().
+		CreatePush(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	err := resourceGitRepositoryFileDelete(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that the delete operation is considered failed if the push fails
+func TestGitRepositoryFile_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryFileResourceData(t)
+	resourceData.SetId("an-id")
+
+	expectExistingFile(reposClient, clients.Ctx)
+	expectBranchTip(reposClient, clients.Ctx)
+	reposClient.
+		EXPECT().
+		CreatePush(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePush() Failed")).
+		Times(1)
+
+	err := resourceGitRepositoryFileDelete(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "CreatePush() Failed")
+	require.Equal(t, "an-id", resourceData.Id())
+}
@@ -0,0 +1,206 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+)
+
+// ResourceGitRepositoryTag schema and implementation for annotated and lightweight tags of a git repository
+func ResourceGitRepositoryTag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitRepositoryTagCreate,
+		Read:   resourceGitRepositoryTagRead,
+		Delete: resourceGitRepositoryTagDelete,
+		Importer: &schema.ResourceImporter{
+			State: importGitRepositoryTag,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"repository_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.IsUUID,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsNotWhiteSpace,
+				DiffSuppressFunc: suppressTagsPrefixDifference,
+			},
+			"message": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsNotWhiteSpace,
+				DiffSuppressFunc: suppress.SpaceDifference,
+			},
+			"ref_branch": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsNotWhiteSpace,
+				DiffSuppressFunc: suppressSourceRefDiffAfterImport,
+				ExactlyOneOf:     []string{"ref_branch", "ref_commit_id"},
+			},
+			"ref_commit_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexpCommitID, "Commit ID must be a full SHA-1 hash"),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return suppress.CaseDifference(k, old, new, d) || suppressSourceRefDiffAfterImport(k, old, new, d)
+				},
+				ExactlyOneOf: []string{"ref_branch", "ref_commit_id"},
+			},
+			"ref": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"object_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"commit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGitRepositoryTagCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	name := strings.TrimPrefix(d.Get("name").(string), tagsPrefix)
+	refName := withTagsPrefix(name)
+
+	commitID, err := resolveGitCommitID(clients, projectID, repositoryID,
+		d.Get("ref_branch").(string),
+		"",
+		d.Get("ref_commit_id").(string))
+	if err != nil {
+		return err
+	}
+
+	if message, ok := d.GetOk("message"); ok {
+		_, err = clients.GitReposClient.CreateAnnotatedTag(clients.Ctx, git.CreateAnnotatedTagArgs{
+			TagObject: &git.GitAnnotatedTag{
+				Name:    converter.String(name),
+				Message: converter.String(message.(string)),
+				TaggedObject: &git.GitObject{
+					ObjectId: converter.String(commitID),
+				},
+			},
+			Project:      converter.String(projectID),
+			RepositoryId: converter.String(repositoryID),
+		})
+	} else {
+		err = createGitRef(clients, projectID, repositoryID, refName, commitID)
+	}
+	if err != nil {
+		return fmt.Errorf("Error creating tag %s in repository %s: %+v", refName, repositoryID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", repositoryID, refName))
+	return resourceGitRepositoryTagRead(d, m)
+}
+
+func resourceGitRepositoryTagRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	refName := withTagsPrefix(d.Get("name").(string))
+
+	ref, err := getGitRef(clients, projectID, repositoryID, refName)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading tag %s in repository %s: %+v", refName, repositoryID, err)
+	}
+	if ref == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("ref", ref.Name)
+	d.Set("object_id", ref.ObjectId)
+
+	// only annotated tags are peeled to the commit they point to
+	peeledObjectID := converter.ToString(ref.PeeledObjectId, "")
+	if peeledObjectID == "" {
+		d.Set("commit_id", ref.ObjectId)
+		d.Set("message", "")
+		return nil
+	}
+
+	tag, err := clients.GitReposClient.GetAnnotatedTag(clients.Ctx, git.GetAnnotatedTagArgs{
+		Project:      converter.String(projectID),
+		RepositoryId: converter.String(repositoryID),
+		ObjectId:     ref.ObjectId,
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading annotated tag %s in repository %s: %+v", refName, repositoryID, err)
+	}
+
+	d.Set("commit_id", peeledObjectID)
+	// git terminates the message with a new line, which is not part of most configurations
+	d.Set("message", converter.ToString(tag.Message, ""))
+	return nil
+}
+
+func resourceGitRepositoryTagDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+	refName := withTagsPrefix(d.Get("name").(string))
+
+	ref, err := getGitRef(clients, projectID, repositoryID, refName)
+	if err != nil {
+		return fmt.Errorf("Error reading tag %s in repository %s: %+v", refName, repositoryID, err)
+	}
+	if ref == nil {
+		d.SetId("")
+		return nil
+	}
+
+	err = deleteGitRef(clients, projectID, repositoryID, refName, ref.ObjectId)
+	if err != nil {
+		return fmt.Errorf("Error deleting tag %s in repository %s: %+v", refName, repositoryID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func importGitRepositoryTag(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	projectID, repositoryID, tagName, err := parseGitRefImportID(d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("repository_id", repositoryID)
+	d.Set("name", strings.TrimPrefix(tagName, tagsPrefix))
+	d.SetId(fmt.Sprintf("%s:%s", repositoryID, withTagsPrefix(tagName)))
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,220 @@
+// +build all git resource_git_repository_tag
+// +build !exclude_git !exclude_resource_git_repository_tag
+
+package git
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testTagProjectID = uuid.New().String()
+var testTagRepoID = uuid.New().String()
+
+const testTagCommitID = "0123456789abcdef0123456789abcdef01234567"
+const testTagObjectID = "fedcba9876543210fedcba9876543210fedcba98"
+
+func getRepositoryTagResourceData(t *testing.T) *schema.ResourceData {
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepositoryTag().Schema, nil)
+	resourceData.Set("project_id", testTagProjectID)
+	resourceData.Set("repository_id", testTagRepoID)
+	resourceData.Set("name", "v1.0")
+	resourceData.Set("ref_commit_id", testTagCommitID)
+	return resourceData
+}
+
+// verifies that an annotated tag is created if a message is configured
+func TestGitRepositoryTag_Create_AnnotatedTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryTagResourceData(t)
+	resourceData.Set("message", "Release 1.0")
+
+	expectedArgs := git.CreateAnnotatedTagArgs{
+		TagObject: &git.GitAnnotatedTag{
+			Name:    converter.String("v1.0"),
+			Message: converter.String("Release 1.0"),
+			TaggedObject: &git.GitObject{
+				ObjectId: converter.String(testTagCommitID),
+			},
+		},
+		Project:      converter.String(testTagProjectID),
+		RepositoryId: converter.String(testTagRepoID),
+	}
+	reposClient.
+		EXPECT().
+		CreateAnnotatedTag(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("CreateAnnotatedTag() Failed")).
+		Times(1)
+	reposClient.
+		EXPECT().
+		UpdateRefs(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := resourceGitRepositoryTagCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "CreateAnnotatedTag() Failed")
+}
+
+// verifies that a lightweight tag is created if no message is configured
+func TestGitRepositoryTag_Create_LightweightTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryTagResourceData(t)
+
+	expectedArgs := git.UpdateRefsArgs{
+		RefUpdates: &[]git.GitRefUpdate{
+			{
+				Name:        converter.String("refs/tags/v1.0"),
+				OldObjectId: converter.String(emptyObjectID),
+				NewObjectId: converter.String(testTagCommitID),
+			},
+		},
+		RepositoryId: converter.String(testTagRepoID),
+		Project:      converter.String(testTagProjectID),
+	}
+	reposClient.
+		EXPECT().
+		UpdateRefs(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateRefs() Failed")).
+		Times(1)
+	reposClient.
+		EXPECT().
+		CreateAnnotatedTag(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := resourceGitRepositoryTagCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "UpdateRefs() Failed")
+}
+
+// verifies that the message and the tagged commit of an annotated tag are read
+func TestGitRepositoryTag_Read_AnnotatedTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryTagResourceData(t)
+	resourceData.SetId(testTagRepoID + ":refs/tags/v1.0")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{
+					Name:           converter.String("refs/tags/v1.0"),
+					ObjectId:       converter.String(testTagObjectID),
+					PeeledObjectId: converter.String(testTagCommitID),
+				},
+			},
+		}, nil).
+		Times(1)
+	reposClient.
+		EXPECT().
+		GetAnnotatedTag(clients.Ctx, git.GetAnnotatedTagArgs{
+			Project:      converter.String(testTagProjectID),
+			RepositoryId: converter.String(testTagRepoID),
+			ObjectId:     converter.String(testTagObjectID),
+		}).
+		Return(&git.GitAnnotatedTag{Message: converter.String("Release 1.0\n")}, nil).
+		Times(1)
+
+	err := resourceGitRepositoryTagRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "Release 1.0\n", resourceData.Get("message"))
+	require.Equal(t, testTagObjectID, resourceData.Get("object_id"))
+	require.Equal(t, testTagCommitID, resourceData.Get("commit_id"))
+}
+
+// verifies that the new line terminating the message of an annotated tag does not replace the tag
+func TestGitRepositoryTag_Diff_IgnoresTrailingNewLineOfMessage(t *testing.T) {
+	for _, message := range []string{"Release 1.0", "Release 1.0\n"} {
+		state := &terraform.InstanceState{
+			ID: testTagRepoID + ":refs/tags/v1.0",
+			Attributes: map[string]string{
+				"project_id":    testTagProjectID,
+				"repository_id": testTagRepoID,
+				"name":          "v1.0",
+				"message":       "Release 1.0\n",
+				"ref_branch":    "refs/heads/master",
+			},
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project_id":    testTagProjectID,
+			"repository_id": testTagRepoID,
+			"name":          "v1.0",
+			"message":       message,
+			"ref_branch":    "refs/heads/master",
+		})
+
+		diff, err := ResourceGitRepositoryTag().Diff(state, config, nil)
+		require.Nil(t, err)
+		require.Nil(t, diff, "message %q", message)
+	}
+}
+
+// verifies that a tag which has been deleted outside of Terraform is removed from the state
+func TestGitRepositoryTag_Read_RemovesDeletedTagFromState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := getRepositoryTagResourceData(t)
+	resourceData.SetId(testTagRepoID + ":refs/tags/v1.0")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{}, nil).
+		Times(1)
+
+	err := resourceGitRepositoryTagRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that an imported tag is not replaced if the configured name contains the refs/tags/ prefix
+func TestGitRepositoryTag_Diff_IgnoresTagsPrefixOfName(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: testTagRepoID + ":refs/tags/v1.0",
+		Attributes: map[string]string{
+			"project_id":    testTagProjectID,
+			"repository_id": testTagRepoID,
+			"name":          "v1.0",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":    testTagProjectID,
+		"repository_id": testTagRepoID,
+		"name":          "refs/tags/v1.0",
+		"ref_branch":    "refs/heads/master",
+	})
+
+	diff, err := ResourceGitRepositoryTag().Diff(state, config, nil)
+	require.Nil(t, err)
+	require.Nil(t, diff)
+}
@@ -7,16 +7,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/serviceendpoint"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/testhelper"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -300,3 +308,397 @@ func TestGitRepo_Read_UsesNameIfIdNotSet(t *testing.T) {
 
 	resourceGitRepositoryRead(resourceData, clients)
 }
+
+// verifies that an import passes the service connection to the import request and
+// surfaces the error message of a failed import
+func TestGitRepo_Import_SurfacesFailedImportRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serviceConnectionID := uuid.New()
+	importRequestID := 42
+	repo := &git.GitRepository{
+		Id:   &testRepoID,
+		Name: converter.String("RepoName"),
+	}
+	initialization := &repoInitializationMeta{
+		initType:            "Import",
+		sourceType:          "Git",
+		sourceURL:           "https://github.com/microsoft/terraform-provider-azuredevops.git",
+		serviceConnectionID: serviceConnectionID.String(),
+	}
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	reposClient.
+		EXPECT().
+		CreateImportRequest(clients.Ctx, git.CreateImportRequestArgs{
+			ImportRequest: &git.GitImportRequest{
+				Parameters: &git.GitImportRequestParameters{
+					GitSource: &git.GitImportGitSource{
+						Url: &initialization.sourceURL,
+					},
+					ServiceEndpointId: &serviceConnectionID,
+				},
+				Repository: repo,
+			},
+			Project:      converter.String(testRepoProjectID.String()),
+			RepositoryId: repo.Name,
+		}).
+		Return(&git.GitImportRequest{ImportRequestId: &importRequestID}, nil).
+		Times(1)
+
+	reposClient.
+		EXPECT().
+		GetImportRequest(clients.Ctx, git.GetImportRequestArgs{
+			Project:         converter.String(testRepoProjectID.String()),
+			RepositoryId:    converter.String(testRepoID.String()),
+			ImportRequestId: &importRequestID,
+		}).
+		Return(&git.GitImportRequest{
+			ImportRequestId: &importRequestID,
+			Status:          &git.GitAsyncOperationStatusValues.Failed,
+			DetailedStatus: &git.GitImportStatusDetail{
+				ErrorMessage: converter.String("Authentication failed"),
+			},
+		}, nil).
+		Times(1)
+
+	err := importGitRepository(clients, repo, testRepoProjectID.String(), initialization, time.Minute)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Authentication failed")
+}
+
+// verifies that the temporary service connection for an import is deleted if the import request cannot be created
+func TestGitRepo_Import_DeletesServiceConnectionIfImportRequestFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serviceConnectionID := uuid.New()
+	repo := &git.GitRepository{
+		Id:   &testRepoID,
+		Name: converter.String("RepoName"),
+	}
+	initialization := &repoInitializationMeta{
+		initType:   "Import",
+		sourceType: "Git",
+		sourceURL:  "https://github.com/microsoft/terraform-provider-azuredevops.git",
+		username:   "user",
+		password:   "password",
+	}
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	serviceEndpointClient := azdosdkmocks.NewMockServiceendpointClient(ctrl)
+	clients := &client.AggregatedClient{
+		GitReposClient:        reposClient,
+		ServiceEndpointClient: serviceEndpointClient,
+		Ctx:                   context.Background(),
+	}
+
+	serviceEndpointClient.
+		EXPECT().
+		CreateServiceEndpoint(clients.Ctx, gomock.Any()).
+		Return(&serviceendpoint.ServiceEndpoint{Id: &serviceConnectionID}, nil).
+		Times(1)
+
+	reposClient.
+		EXPECT().
+		CreateImportRequest(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreateImportRequest() Failed")).
+		Times(1)
+
+	serviceEndpointClient.
+		EXPECT().
+		DeleteServiceEndpoint(clients.Ctx, serviceendpoint.DeleteServiceEndpointArgs{
+			Project:    converter.String(testRepoProjectID.String()),
+			EndpointId: &serviceConnectionID,
+		}).
+		Return(nil).
+		Times(1)
+
+	err := importGitRepository(clients, repo, testRepoProjectID.String(), initialization, time.Minute)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "CreateImportRequest() Failed")
+}
+
+// verifies that a new repository is deleted if its import fails, so that it does not block the next apply
+func TestGitRepo_Create_DeletesRepositoryIfImportFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepository().Schema, nil)
+	resourceData.Set("project_id", testRepoProjectID.String())
+	resourceData.Set("name", "RepoName")
+	resourceData.Set("initialization", []interface{}{
+		map[string]interface{}{
+			"init_type":             "Import",
+			"source_type":           "Git",
+			"source_url":            "https://github.com/microsoft/terraform-provider-azuredevops.git",
+			"service_connection_id": uuid.New().String(),
+		},
+	})
+
+	reposClient.
+		EXPECT().
+		CreateRepository(clients.Ctx, gomock.Any()).
+		Return(&testGitRepository, nil).
+		Times(1)
+
+	reposClient.
+		EXPECT().
+		CreateImportRequest(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreateImportRequest() Failed")).
+		Times(1)
+
+	reposClient.
+		EXPECT().
+		DeleteRepository(clients.Ctx, git.DeleteRepositoryArgs{
+			RepositoryId: &testRepoID,
+		}).
+		Return(nil).
+		Times(1)
+
+	err := resourceGitRepositoryCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "CreateImportRequest() Failed")
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that an imported repository, whose state does not contain the initialization, is renamed in place
+func TestGitRepo_Diff_RenamesImportedRepositoryInPlace(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: testRepoID.String(),
+		Attributes: map[string]string{
+			"id":         testRepoID.String(),
+			"project_id": testRepoProjectID.String(),
+			"name":       "RepoName",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": testRepoProjectID.String(),
+		"name":       "NewRepoName",
+		"initialization": []interface{}{
+			map[string]interface{}{
+				"init_type":   "Import",
+				"source_type": "Git",
+				"source_url":  "https://github.com/microsoft/terraform-provider-azuredevops.git",
+				"username":    "user",
+				"password":    "password",
+			},
+		},
+	})
+
+	diff, err := ResourceGitRepository().Diff(state, config, nil)
+	require.Nil(t, err)
+	require.False(t, diff.RequiresNew())
+	require.Equal(t, "NewRepoName", diff.Attributes["name"].New)
+
+	// the repository is still replaced if the source of an import changes
+	state.Attributes["initialization.#"] = "1"
+	state.Attributes["initialization.0.init_type"] = "Import"
+	state.Attributes["initialization.0.source_type"] = "Git"
+	state.Attributes["initialization.0.source_url"] = "https://github.com/microsoft/azure-devops-go-api.git"
+	diff, err = ResourceGitRepository().Diff(state, config, nil)
+	require.Nil(t, err)
+	require.True(t, diff.RequiresNew())
+}
+
+// verifies that a disabled repository, which cannot be read directly, is looked up in the hidden repositories
+func TestGitRepo_Read_FindsDisabledRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the fork settings are not read while forks are allowed, so no policy client is required
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepository().Schema, nil)
+	resourceData.SetId(testRepoID.String())
+	resourceData.Set("project_id", testRepoProjectID.String())
+
+	reposClient.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(404)}).
+		Times(1)
+
+	reposClient.
+		EXPECT().
+		GetRepositories(clients.Ctx, git.GetRepositoriesArgs{
+			Project:       converter.String(testRepoProjectID.String()),
+			IncludeHidden: converter.Bool(true),
+		}).
+		Return(&[]git.GitRepository{testGitRepository}, nil).
+		Times(1)
+
+	err := resourceGitRepositoryRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testRepoID.String(), resourceData.Id())
+	require.True(t, resourceData.Get("is_disabled").(bool))
+	require.True(t, resourceData.Get("allow_forks").(bool))
+}
+
+// verifies that the fork settings are read during an import, when the state does not contain them yet
+func TestGitRepo_Read_ReadsForkSettingsDuringImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		GitReposClient: reposClient,
+		PolicyClient:   policyClient,
+		Ctx:            context.Background(),
+	}
+
+	resourceData := ResourceGitRepository().Data(&terraform.InstanceState{
+		ID: testRepoID.String(),
+		Attributes: map[string]string{
+			"project_id": testRepoProjectID.String(),
+		},
+	})
+
+	reposClient.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(&testGitRepository, nil).
+		Times(1)
+
+	policyClient.
+		EXPECT().
+		GetPolicyConfigurations(clients.Ctx, policy.GetPolicyConfigurationsArgs{
+			Project:    converter.String(testRepoProjectID.String()),
+			PolicyType: &gitRepositorySettingsPolicyType,
+		}).
+		Return(&policy.GetPolicyConfigurationsResponseValue{
+			Value: []policy.PolicyConfiguration{
+				{
+					Id: converter.Int(1),
+					Settings: map[string]interface{}{
+						"allowedForkTargets": float64(0),
+						"scope": []interface{}{
+							map[string]interface{}{"repositoryId": testRepoID.String()},
+						},
+					},
+				},
+			},
+		}, nil).
+		Times(1)
+
+	err := resourceGitRepositoryRead(resourceData, clients)
+	require.Nil(t, err)
+	require.False(t, resourceData.Get("allow_forks").(bool))
+}
+
+// verifies that a fork sync request maps the source branch of the parent repository to the target branch of the fork
+func TestGitRepo_ForkSync_WaitsForCompletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	parentRepoID := uuid.New()
+	parentProjectID := uuid.New()
+	operationID := 7
+
+	reposClient.
+		EXPECT().
+		GetRepository(clients.Ctx, git.GetRepositoryArgs{
+			RepositoryId: converter.String(parentRepoID.String()),
+			Project:      converter.String(""),
+		}).
+		Return(&git.GitRepository{
+			Id:      &parentRepoID,
+			Project: &core.TeamProjectReference{Id: &parentProjectID},
+		}, nil).
+		Times(1)
+
+	reposClient.
+		EXPECT().
+		CreateForkSyncRequest(clients.Ctx, git.CreateForkSyncRequestArgs{
+			SyncParams: &git.GitForkSyncRequestParameters{
+				Source: &git.GlobalGitRepositoryKey{
+					ProjectId:    &parentProjectID,
+					RepositoryId: &parentRepoID,
+				},
+				SourceToTargetRefs: &[]git.SourceToTargetRef{
+					{
+						SourceRef: converter.String("refs/heads/master"),
+						TargetRef: converter.String("refs/heads/upstream"),
+					},
+				},
+			},
+			RepositoryNameOrId: converter.String(testRepoID.String()),
+			Project:            converter.String(testRepoProjectID.String()),
+		}).
+		Return(&git.GitForkSyncRequest{OperationId: &operationID}, nil).
+		Times(1)
+
+	reposClient.
+		EXPECT().
+		GetForkSyncRequest(clients.Ctx, git.GetForkSyncRequestArgs{
+			RepositoryNameOrId:  converter.String(testRepoID.String()),
+			ForkSyncOperationId: &operationID,
+			Project:             converter.String(testRepoProjectID.String()),
+		}).
+		Return(&git.GitForkSyncRequest{
+			OperationId: &operationID,
+			Status:      &git.GitAsyncOperationStatusValues.Completed,
+		}, nil).
+		Times(1)
+
+	status, err := syncGitRepositoryFork(clients, testRepoID.String(), testRepoProjectID.String(), parentRepoID.String(), &repoForkSyncMeta{
+		sourceBranch: "refs/heads/master",
+		targetBranch: "refs/heads/upstream",
+	}, time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, string(git.GitAsyncOperationStatusValues.Completed), status)
+}
+
+// verifies that a repository which stays disabled is enabled for the update and disabled again, even if the update fails
+func TestGitRepo_Update_EnablesDisabledRepositoryForChanges(t *testing.T) {
+	var requests []map[string]interface{}
+	server := testhelper.NewRestServer(t, map[uuid.UUID]string{
+		gitRepositoriesLocationID: "{project}/_apis/git/repositories/{repositoryId}",
+	}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/"+testRepoProjectID.String()+"/_apis/git/repositories/"+testRepoID.String(), r.URL.Path)
+
+		var body map[string]interface{}
+		testhelper.ReadRestRequestBody(t, r, &body)
+		requests = append(requests, body)
+		if _, ok := body["isDisabled"]; ok {
+			testhelper.WriteRestResponse(t, w, http.StatusOK, body)
+			return
+		}
+		testhelper.WriteRestError(t, w, http.StatusInternalServerError, "UpdateRepository() Failed")
+	})
+	defer server.Close()
+
+	clients := &client.AggregatedClient{
+		GitReposClient: &git.ClientImpl{Client: *server.Client},
+		Ctx:            context.Background(),
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepository().Schema, nil)
+	resourceData.SetId(testRepoID.String())
+	resourceData.Set("project_id", testRepoProjectID.String())
+	resourceData.Set("name", "RepoName")
+	resourceData.Set("is_disabled", true)
+	resourceData = ResourceGitRepository().Data(resourceData.State())
+	resourceData.Set("name", "NewRepoName")
+
+	err := resourceGitRepositoryUpdate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "UpdateRepository() Failed")
+
+	require.Len(t, requests, 3)
+	require.Equal(t, map[string]interface{}{"isDisabled": false}, requests[0])
+	require.Equal(t, "NewRepoName", requests[1]["name"])
+	require.Equal(t, map[string]interface{}{"isDisabled": true}, requests[2])
+}
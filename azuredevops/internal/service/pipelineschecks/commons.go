@@ -0,0 +1,312 @@
+package pipelineschecks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// The checks of protected resources are not part of the SDK. They are configured through the
+// check configurations REST endpoints of the pipelines checks area.
+var checkConfigurationsLocationID = uuid.MustParse("86c8381e-5aee-4cde-8ae4-25c0c7f5eaea")
+
+const checkConfigurationsAPIVersion = "5.1-preview.1"
+
+// The types of the checks. Branch control and business hours are evaluated by built-in tasks of the generic task check.
+var (
+	approvalCheckType = checkType{
+		Id:   converter.String("8c6f20a7-a545-4486-9777-f762fafe0d4d"),
+		Name: converter.String("Approval"),
+	}
+	taskCheckType = checkType{
+		Id:   converter.String("fe1de3ee-a436-41b4-bb20-f6eb4cb879a7"),
+		Name: converter.String("Task Check"),
+	}
+	requiredTemplateCheckType = checkType{
+		Id:   converter.String("4020e66e-b0f3-47e1-bc88-48f3cc59b5f3"),
+		Name: converter.String("ExtendsCheck"),
+	}
+)
+
+// The types of the protected resources a check can be attached to
+var checkTargetResourceTypes = []string{"environment", "endpoint", "variablegroup", "queue"}
+
+type checkConfiguration struct {
+	Id       *int            `json:"id,omitempty"`
+	Version  *int            `json:"version,omitempty"`
+	Type     *checkType      `json:"type,omitempty"`
+	Resource *checkResource  `json:"resource,omitempty"`
+	Settings json.RawMessage `json:"settings,omitempty"`
+	Timeout  *int            `json:"timeout,omitempty"`
+}
+
+type checkType struct {
+	Id   *string `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+type checkResource struct {
+	Type *string `json:"type,omitempty"`
+	Id   *string `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+type flatFunc func(d *schema.ResourceData, clients *client.AggregatedClient, configuration *checkConfiguration) error
+type expandFunc func(d *schema.ResourceData, clients *client.AggregatedClient) (*checkType, interface{}, error)
+
+// genBaseCheckResource creates a resource for a type of check. The expand function returns the type and the
+// settings of the check, the attributes shared by all checks are handled by the base resource.
+func genBaseCheckResource(f flatFunc, e expandFunc) *schema.Resource {
+	return &schema.Resource{
+		Create:   genCheckCreateFunc(f, e),
+		Read:     genCheckReadFunc(f),
+		Update:   genCheckUpdateFunc(f, e),
+		Delete:   resourceCheckDelete,
+		Importer: tfhelper.ImportProjectQualifiedResourceInteger(),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"target_resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"target_resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(checkTargetResourceTypes, false),
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      43200,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func genCheckCreateFunc(f flatFunc, e expandFunc) schema.CreateFunc {
+	return func(d *schema.ResourceData, m interface{}) error {
+		clients := m.(*client.AggregatedClient)
+		configuration, projectID, err := expandCheckConfiguration(d, clients, e)
+		if err != nil {
+			return err
+		}
+
+		createdConfiguration, err := addCheckConfiguration(clients, projectID, configuration)
+		if err != nil {
+			return fmt.Errorf("Error creating check in project %s: %+v", projectID, err)
+		}
+
+		d.SetId(strconv.Itoa(*createdConfiguration.Id))
+		return genCheckReadFunc(f)(d, m)
+	}
+}
+
+func genCheckReadFunc(f flatFunc) schema.ReadFunc {
+	return func(d *schema.ResourceData, m interface{}) error {
+		clients := m.(*client.AggregatedClient)
+		projectID, checkID, err := tfhelper.ParseProjectIDAndResourceID(d)
+		if err != nil {
+			return err
+		}
+
+		configuration, err := getCheckConfiguration(clients, projectID, checkID)
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				d.SetId("")
+				return nil
+			}
+			return fmt.Errorf("Error reading check %d in project %s: %+v", checkID, projectID, err)
+		}
+
+		flattenCheckConfiguration(d, configuration, projectID)
+		if err := f(d, clients, configuration); err != nil {
+			return fmt.Errorf("Error flattening settings of check %d: %+v", checkID, err)
+		}
+		return nil
+	}
+}
+
+func genCheckUpdateFunc(f flatFunc, e expandFunc) schema.UpdateFunc {
+	return func(d *schema.ResourceData, m interface{}) error {
+		clients := m.(*client.AggregatedClient)
+		configuration, projectID, err := expandCheckConfiguration(d, clients, e)
+		if err != nil {
+			return err
+		}
+
+		_, err = updateCheckConfiguration(clients, projectID, *configuration.Id, configuration)
+		if err != nil {
+			return fmt.Errorf("Error updating check %d in project %s: %+v", *configuration.Id, projectID, err)
+		}
+
+		return genCheckReadFunc(f)(d, m)
+	}
+}
+
+func resourceCheckDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, checkID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	err = deleteCheckConfiguration(clients, projectID, checkID)
+	if err != nil {
+		return fmt.Errorf("Error deleting check %d in project %s: %+v", checkID, projectID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandCheckConfiguration(d *schema.ResourceData, clients *client.AggregatedClient, e expandFunc) (*checkConfiguration, string, error) {
+	checkType, settings, err := e(d, clients)
+	if err != nil {
+		return nil, "", fmt.Errorf("Error expanding settings of check: %+v", err)
+	}
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return nil, "", err
+	}
+
+	configuration := &checkConfiguration{
+		Type: checkType,
+		Resource: &checkResource{
+			Type: converter.String(d.Get("target_resource_type").(string)),
+			Id:   converter.String(d.Get("target_resource_id").(string)),
+		},
+		Settings: settingsJSON,
+		Timeout:  converter.Int(d.Get("timeout").(int)),
+	}
+	if d.Id() != "" {
+		checkID, err := strconv.Atoi(d.Id())
+		if err != nil {
+			return nil, "", fmt.Errorf("Error parsing check ID %s: %+v", d.Id(), err)
+		}
+		configuration.Id = converter.Int(checkID)
+		configuration.Version = converter.Int(d.Get("version").(int))
+	}
+	return configuration, d.Get("project_id").(string), nil
+}
+
+func flattenCheckConfiguration(d *schema.ResourceData, configuration *checkConfiguration, projectID string) {
+	d.SetId(strconv.Itoa(*configuration.Id))
+	d.Set("project_id", projectID)
+	if configuration.Resource != nil {
+		d.Set("target_resource_type", converter.ToString(configuration.Resource.Type, ""))
+		d.Set("target_resource_id", converter.ToString(configuration.Resource.Id, ""))
+	}
+	if configuration.Timeout != nil {
+		d.Set("timeout", *configuration.Timeout)
+	}
+	d.Set("version", converter.ToInt(configuration.Version, 0))
+}
+
+// using: POST https://dev.azure.com/{organization}/{project}/_apis/pipelines/checks/configurations?api-version=5.1-preview.1
+func addCheckConfiguration(clients *client.AggregatedClient, projectID string, configuration *checkConfiguration) (*checkConfiguration, error) {
+	return sendCheckConfigurationRequest(clients, http.MethodPost, map[string]string{
+		"project": projectID,
+	}, nil, configuration)
+}
+
+// using: GET https://dev.azure.com/{organization}/{project}/_apis/pipelines/checks/configurations/{id}?$expand=settings&api-version=5.1-preview.1
+func getCheckConfiguration(clients *client.AggregatedClient, projectID string, checkID int) (*checkConfiguration, error) {
+	queryParams := url.Values{}
+	queryParams.Add("$expand", "settings")
+	return sendCheckConfigurationRequest(clients, http.MethodGet, map[string]string{
+		"project": projectID,
+		"id":      strconv.Itoa(checkID),
+	}, queryParams, nil)
+}
+
+// using: PATCH https://dev.azure.com/{organization}/{project}/_apis/pipelines/checks/configurations/{id}?api-version=5.1-preview.1
+func updateCheckConfiguration(clients *client.AggregatedClient, projectID string, checkID int, configuration *checkConfiguration) (*checkConfiguration, error) {
+	return sendCheckConfigurationRequest(clients, http.MethodPatch, map[string]string{
+		"project": projectID,
+		"id":      strconv.Itoa(checkID),
+	}, nil, configuration)
+}
+
+// using: DELETE https://dev.azure.com/{organization}/{project}/_apis/pipelines/checks/configurations/{id}?api-version=5.1-preview.1
+func deleteCheckConfiguration(clients *client.AggregatedClient, projectID string, checkID int) error {
+	_, err := sendCheckConfigurationRequest(clients, http.MethodDelete, map[string]string{
+		"project": projectID,
+		"id":      strconv.Itoa(checkID),
+	}, nil, nil)
+	return err
+}
+
+func sendCheckConfigurationRequest(clients *client.AggregatedClient, method string, routeValues map[string]string, queryParams url.Values, configuration *checkConfiguration) (*checkConfiguration, error) {
+	request := &utils.RestRequest{
+		Method:          method,
+		LocationID:      checkConfigurationsLocationID,
+		APIVersion:      checkConfigurationsAPIVersion,
+		RouteValues:     routeValues,
+		QueryParameters: queryParams,
+	}
+	if configuration != nil {
+		request.Body = configuration
+	}
+	// the protected resources are part of the distributed task area, whose client is used to send the requests
+	if method == http.MethodDelete {
+		return nil, utils.SendRestRequest(clients.Ctx, clients.TaskAgentClient, request, nil)
+	}
+
+	var result checkConfiguration
+	err := utils.SendRestRequest(clients.Ctx, clients.TaskAgentClient, request, &result)
+	return &result, err
+}
+
+// taskCheckSettings are the settings of a check evaluated by a built-in task
+type taskCheckSettings struct {
+	DefinitionRef *taskCheckDefinitionReference `json:"definitionRef,omitempty"`
+	DisplayName   *string                       `json:"displayName,omitempty"`
+	Inputs        *map[string]string            `json:"inputs,omitempty"`
+	RetryInterval *int                          `json:"retryInterval,omitempty"`
+}
+
+type taskCheckDefinitionReference struct {
+	Id      *string `json:"id,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	Version *string `json:"version,omitempty"`
+}
+
+// decodeTaskCheckSettings decodes the settings of a task check and verifies that the expected task evaluates the check
+func decodeTaskCheckSettings(configuration *checkConfiguration, definitionRef *taskCheckDefinitionReference) (*taskCheckSettings, error) {
+	var settings taskCheckSettings
+	if err := json.Unmarshal(configuration.Settings, &settings); err != nil {
+		return nil, err
+	}
+	if settings.DefinitionRef == nil || converter.ToString(settings.DefinitionRef.Id, "") != *definitionRef.Id {
+		return nil, fmt.Errorf("check %d is not evaluated by the %s task", *configuration.Id, *definitionRef.Name)
+	}
+	if settings.Inputs == nil {
+		settings.Inputs = &map[string]string{}
+	}
+	return &settings, nil
+}
@@ -0,0 +1,236 @@
+// +build all resource_check_approval resource_check_branch_control resource_check_business_hours resource_check_required_template
+
+package pipelineschecks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/graph"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testProjectID = "9083e944-8e9e-405e-960a-c80180aa71e6"
+
+// getTestCheckResourceData returns the resource data of a check attached to an environment
+func getTestCheckResourceData(t *testing.T, r *schema.Resource, attributes map[string]interface{}) *schema.ResourceData {
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, attributes)
+	resourceData.Set("project_id", testProjectID)
+	resourceData.Set("target_resource_type", "environment")
+	resourceData.Set("target_resource_id", "12")
+	return resourceData
+}
+
+// roundtripCheck expands the check, flattens it into new resource data and returns the new resource data
+func roundtripCheck(t *testing.T, r *schema.Resource, resourceData *schema.ResourceData, clients *client.AggregatedClient, e expandFunc, f flatFunc) *schema.ResourceData {
+	configuration, projectID, err := expandCheckConfiguration(resourceData, clients, e)
+	require.Nil(t, err)
+	require.Equal(t, testProjectID, projectID)
+	require.Equal(t, "environment", *configuration.Resource.Type)
+	require.Equal(t, "12", *configuration.Resource.Id)
+
+	configuration.Id = converter.Int(7)
+	configuration.Version = converter.Int(2)
+
+	flattenedData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenCheckConfiguration(flattenedData, configuration, projectID)
+	require.Nil(t, f(flattenedData, clients, configuration))
+	require.Equal(t, "7", flattenedData.Id())
+	require.Equal(t, 2, flattenedData.Get("version"))
+	require.Equal(t, resourceData.Get("timeout"), flattenedData.Get("timeout"))
+	return flattenedData
+}
+
+// verifies that the ID and the version of an existing check are sent with an update
+func TestCheck_Expand_IncludesIDAndVersion(t *testing.T) {
+	resourceData := getTestCheckResourceData(t, ResourceCheckRequiredTemplate(), nil)
+	resourceData.SetId("7")
+	resourceData.Set("version", 3)
+
+	configuration, _, err := expandCheckConfiguration(resourceData, nil, expandRequiredTemplateCheck)
+	require.Nil(t, err)
+	require.Equal(t, 7, *configuration.Id)
+	require.Equal(t, 3, *configuration.Version)
+	require.Equal(t, requiredTemplateCheckType, *configuration.Type)
+}
+
+var testApproverDescriptor = "aad.NzQ5ZTc1NDEtZjY5Yi03NzE4LThiZjctMmM0ZDhiMTk5NTc5"
+var testApproverStorageKey = uuid.MustParse("0b1c7f6f-4c4e-4f83-9a53-2a8b0a2e3f41")
+
+type testCheck struct {
+	Resource   *schema.Resource
+	Attributes map[string]interface{}
+}
+
+// getTestChecks returns each type of check with a valid configuration
+func getTestChecks() map[string]testCheck {
+	return map[string]testCheck{
+		"Approval": {
+			Resource: ResourceCheckApproval(),
+			Attributes: map[string]interface{}{
+				"approvers": []interface{}{testApproverDescriptor},
+			},
+		},
+		"BranchControl": {
+			Resource: ResourceCheckBranchControl(),
+			Attributes: map[string]interface{}{
+				"allowed_branches": "refs/heads/main",
+			},
+		},
+		"BusinessHours": {
+			Resource: ResourceCheckBusinessHours(),
+			Attributes: map[string]interface{}{
+				"business_days": []interface{}{"monday", "friday"},
+				"time_zone":     "UTC",
+				"start_time":    "08:00",
+				"end_time":      "17:00",
+			},
+		},
+		"RequiredTemplate": {
+			Resource: ResourceCheckRequiredTemplate(),
+			Attributes: map[string]interface{}{
+				"required_template": []interface{}{
+					map[string]interface{}{
+						"repository_type": "git",
+						"repository_name": "project/templates",
+						"repository_ref":  "refs/heads/main",
+						"template_path":   "deploy.yml",
+					},
+				},
+			},
+		},
+	}
+}
+
+// getTestCheckClients returns clients which send the check configurations to a fake service and resolve the approver with a mocked graph client
+func getTestCheckClients(t *testing.T, ctrl *gomock.Controller, handler http.HandlerFunc) (*client.AggregatedClient, *testhelper.RestServer) {
+	server := testhelper.NewRestServer(t, map[uuid.UUID]string{
+		checkConfigurationsLocationID: "{project}/_apis/pipelines/checks/configurations/{id}",
+	}, handler)
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{
+		GraphClient:     graphClient,
+		TaskAgentClient: &taskagent.ClientImpl{Client: *server.Client},
+		Ctx:             context.Background(),
+	}
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: converter.String(testApproverDescriptor)}).
+		Return(&graph.GraphStorageKeyResult{Value: &testApproverStorageKey}, nil).
+		AnyTimes()
+	graphClient.
+		EXPECT().
+		GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &testApproverStorageKey}).
+		Return(&graph.GraphDescriptorResult{Value: converter.String(testApproverDescriptor)}, nil).
+		AnyTimes()
+	return clients, server
+}
+
+// verifies that a check is created without a version and updated with the version read from the service
+func TestCheck_CreateUpdate_SendsVersion(t *testing.T) {
+	configurationsPath := "/" + testProjectID + "/_apis/pipelines/checks/configurations"
+
+	for name, check := range getTestChecks() {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var storedConfiguration checkConfiguration
+			clients, server := getTestCheckClients(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == configurationsPath:
+					testhelper.ReadRestRequestBody(t, r, &storedConfiguration)
+					assert.Nil(t, storedConfiguration.Id)
+					assert.Nil(t, storedConfiguration.Version)
+					storedConfiguration.Id = converter.Int(7)
+					storedConfiguration.Version = converter.Int(1)
+				case r.Method == http.MethodPatch && r.URL.Path == configurationsPath+"/7":
+					var configuration checkConfiguration
+					testhelper.ReadRestRequestBody(t, r, &configuration)
+					assert.Equal(t, 7, converter.ToInt(configuration.Id, 0))
+					assert.Equal(t, *storedConfiguration.Version, converter.ToInt(configuration.Version, 0))
+					assert.JSONEq(t, string(storedConfiguration.Settings), string(configuration.Settings))
+					storedConfiguration.Version = converter.Int(*storedConfiguration.Version + 1)
+				case r.Method == http.MethodGet && r.URL.Path == configurationsPath+"/7":
+					assert.Equal(t, "settings", r.URL.Query().Get("$expand"))
+				default:
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+					http.NotFound(w, r)
+					return
+				}
+				testhelper.WriteRestResponse(t, w, http.StatusOK, storedConfiguration)
+			})
+			defer server.Close()
+
+			resourceData := getTestCheckResourceData(t, check.Resource, check.Attributes)
+			err := check.Resource.Create(resourceData, clients)
+			require.Nil(t, err)
+			require.Equal(t, "7", resourceData.Id())
+			require.Equal(t, 1, resourceData.Get("version"))
+
+			err = check.Resource.Update(resourceData, clients)
+			require.Nil(t, err)
+			require.Equal(t, 2, resourceData.Get("version"))
+		})
+	}
+}
+
+// verifies that a check deleted outside of Terraform is removed from the state
+func TestCheck_Read_RemovesDeletedCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, server := getTestCheckClients(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		testhelper.WriteRestError(t, w, http.StatusNotFound, "Check configuration 7 not found")
+	})
+	defer server.Close()
+
+	r := ResourceCheckBranchControl()
+	resourceData := getTestCheckResourceData(t, r, nil)
+	resourceData.SetId("7")
+
+	err := r.Read(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+func TestCheck_DoesNotSwallowError(t *testing.T) {
+	for name, check := range getTestChecks() {
+		r := check.Resource
+		functionsUnderTest := map[string]func(*schema.ResourceData, interface{}) error{
+			"Create": r.Create,
+			"Read":   r.Read,
+			"Update": r.Update,
+			"Delete": r.Delete,
+		}
+		for functionName, functionUnderTest := range functionsUnderTest {
+			t.Run(name+functionName, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				clients, server := getTestCheckClients(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+					testhelper.WriteRestError(t, w, http.StatusInternalServerError, "CheckConfiguration() Failed")
+				})
+				defer server.Close()
+
+				resourceData := getTestCheckResourceData(t, r, check.Attributes)
+				resourceData.SetId("7")
+
+				err := functionUnderTest(resourceData, clients)
+				require.NotNil(t, err)
+				require.Contains(t, err.Error(), "CheckConfiguration() Failed")
+			})
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package pipelineschecks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/graph"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+type approvalCheckSettings struct {
+	Approvers                 *[]approvalCheckIdentity `json:"approvers,omitempty"`
+	BlockedApprovers          *[]approvalCheckIdentity `json:"blockedApprovers,omitempty"`
+	ExecutionOrder            *string                  `json:"executionOrder,omitempty"`
+	Instructions              *string                  `json:"instructions,omitempty"`
+	MinRequiredApprovers      *int                     `json:"minRequiredApprovers,omitempty"`
+	RequesterCannotBeApprover *bool                    `json:"requesterCannotBeApprover,omitempty"`
+}
+
+// approvalCheckIdentity references an approver by the storage key of its descriptor
+type approvalCheckIdentity struct {
+	Id *string `json:"id,omitempty"`
+}
+
+// ResourceCheckApproval schema and implementation for the approval check of a protected resource
+func ResourceCheckApproval() *schema.Resource {
+	r := genBaseCheckResource(flattenApprovalCheck, expandApprovalCheck)
+	r.Schema["approvers"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+	}
+	r.Schema["instructions"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	r.Schema["minimum_required_approvers"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      0,
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+	r.Schema["requester_can_approve"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	r.Schema["approve_in_sequence"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	return r
+}
+
+func expandApprovalCheck(d *schema.ResourceData, clients *client.AggregatedClient) (*checkType, interface{}, error) {
+	approvers := []approvalCheckIdentity{}
+	for _, descriptor := range d.Get("approvers").([]interface{}) {
+		storageKey, err := clients.GraphClient.GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{
+			SubjectDescriptor: converter.String(descriptor.(string)),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error resolving approver %s: %+v", descriptor, err)
+		}
+		approvers = append(approvers, approvalCheckIdentity{Id: converter.String(storageKey.Value.String())})
+	}
+
+	executionOrder := "anyOrder"
+	if d.Get("approve_in_sequence").(bool) {
+		executionOrder = "inSequence"
+	}
+
+	return &approvalCheckType, &approvalCheckSettings{
+		Approvers:                 &approvers,
+		BlockedApprovers:          &[]approvalCheckIdentity{},
+		ExecutionOrder:            converter.String(executionOrder),
+		Instructions:              converter.String(d.Get("instructions").(string)),
+		MinRequiredApprovers:      converter.Int(d.Get("minimum_required_approvers").(int)),
+		RequesterCannotBeApprover: converter.Bool(!d.Get("requester_can_approve").(bool)),
+	}, nil
+}
+
+func flattenApprovalCheck(d *schema.ResourceData, clients *client.AggregatedClient, configuration *checkConfiguration) error {
+	var settings approvalCheckSettings
+	if err := json.Unmarshal(configuration.Settings, &settings); err != nil {
+		return err
+	}
+
+	approvers := []interface{}{}
+	if settings.Approvers != nil {
+		for _, approver := range *settings.Approvers {
+			storageKey, err := uuid.Parse(converter.ToString(approver.Id, ""))
+			if err != nil {
+				return fmt.Errorf("Error parsing ID of approver %s: %+v", converter.ToString(approver.Id, ""), err)
+			}
+			descriptor, err := clients.GraphClient.GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{
+				StorageKey: &storageKey,
+			})
+			if err != nil {
+				return fmt.Errorf("Error resolving descriptor of approver %s: %+v", storageKey, err)
+			}
+			approvers = append(approvers, converter.ToString(descriptor.Value, ""))
+		}
+	}
+
+	d.Set("approvers", approvers)
+	d.Set("instructions", converter.ToString(settings.Instructions, ""))
+	d.Set("minimum_required_approvers", converter.ToInt(settings.MinRequiredApprovers, 0))
+	d.Set("requester_can_approve", !converter.ToBool(settings.RequesterCannotBeApprover, false))
+	d.Set("approve_in_sequence", converter.ToString(settings.ExecutionOrder, "") == "inSequence")
+	return nil
+}
@@ -0,0 +1,90 @@
+// +build all resource_check_approval
+// +build !exclude_resource_check_approval
+
+package pipelineschecks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/graph"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+// verifies that the descriptors of the approvers are sent as storage keys and resolved again when read
+func TestCheckApproval_ExpandFlatten_ResolvesDescriptors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	descriptor := "aad.NzQ5ZTc1NDEtZjY5Yi03NzE4LThiZjctMmM0ZDhiMTk5NTc5"
+	storageKey := uuid.New()
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: converter.String(descriptor)}).
+		Return(&graph.GraphStorageKeyResult{Value: &storageKey}, nil).
+		Times(1)
+	graphClient.
+		EXPECT().
+		GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &storageKey}).
+		Return(&graph.GraphDescriptorResult{Value: converter.String(descriptor)}, nil).
+		Times(1)
+
+	r := ResourceCheckApproval()
+	resourceData := getTestCheckResourceData(t, r, nil)
+	resourceData.Set("approvers", []interface{}{descriptor})
+	resourceData.Set("instructions", "Approve the deployment")
+	resourceData.Set("minimum_required_approvers", 1)
+	resourceData.Set("approve_in_sequence", true)
+	resourceData.Set("timeout", 600)
+
+	_, settings, err := expandApprovalCheck(resourceData, clients)
+	require.Nil(t, err)
+	approvalSettings := settings.(*approvalCheckSettings)
+	require.Equal(t, storageKey.String(), *(*approvalSettings.Approvers)[0].Id)
+	require.Equal(t, "inSequence", *approvalSettings.ExecutionOrder)
+	require.True(t, *approvalSettings.RequesterCannotBeApprover)
+
+	// the storage key is resolved a second time by the roundtrip
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, gomock.Any()).
+		Return(&graph.GraphStorageKeyResult{Value: &storageKey}, nil).
+		Times(1)
+	flattenedData := roundtripCheck(t, r, resourceData, clients, expandApprovalCheck, flattenApprovalCheck)
+	require.Equal(t, []interface{}{descriptor}, flattenedData.Get("approvers"))
+	require.Equal(t, "Approve the deployment", flattenedData.Get("instructions"))
+	require.Equal(t, 1, flattenedData.Get("minimum_required_approvers"))
+	require.Equal(t, true, flattenedData.Get("approve_in_sequence"))
+	require.Equal(t, false, flattenedData.Get("requester_can_approve"))
+}
+
+// verifies that a failing lookup of an approver is reported
+func TestCheckApproval_Expand_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetStorageKey() Failed")).
+		Times(1)
+
+	resourceData := getTestCheckResourceData(t, ResourceCheckApproval(), nil)
+	resourceData.Set("approvers", []interface{}{"aad.unknown"})
+
+	_, _, err := expandApprovalCheck(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "GetStorageKey() Failed")
+}
@@ -0,0 +1,72 @@
+package pipelineschecks
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+var branchControlTaskDefinition = taskCheckDefinitionReference{
+	Id:      converter.String("86b05a0c-73e6-4f7d-b3cf-e38f3b39a75b"),
+	Name:    converter.String("evaluatebranchProtection"),
+	Version: converter.String("0.0.1"),
+}
+
+// ResourceCheckBranchControl schema and implementation for the branch control check of a protected resource
+func ResourceCheckBranchControl() *schema.Resource {
+	r := genBaseCheckResource(flattenBranchControlCheck, expandBranchControlCheck)
+	r.Schema["timeout"].Default = 1440
+	r.Schema["display_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "Branch control",
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+	r.Schema["allowed_branches"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "*",
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+	r.Schema["verify_branch_protection"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	r.Schema["ignore_unknown_protection_status"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	return r
+}
+
+func expandBranchControlCheck(d *schema.ResourceData, clients *client.AggregatedClient) (*checkType, interface{}, error) {
+	return &taskCheckType, &taskCheckSettings{
+		DefinitionRef: &branchControlTaskDefinition,
+		DisplayName:   converter.String(d.Get("display_name").(string)),
+		Inputs: &map[string]string{
+			"allowedBranches":          d.Get("allowed_branches").(string),
+			"ensureProtectionOfBranch": strconv.FormatBool(d.Get("verify_branch_protection").(bool)),
+			"allowUnknownStatusBranch": strconv.FormatBool(d.Get("ignore_unknown_protection_status").(bool)),
+		},
+		RetryInterval: converter.Int(5),
+	}, nil
+}
+
+func flattenBranchControlCheck(d *schema.ResourceData, clients *client.AggregatedClient, configuration *checkConfiguration) error {
+	settings, err := decodeTaskCheckSettings(configuration, &branchControlTaskDefinition)
+	if err != nil {
+		return err
+	}
+
+	inputs := *settings.Inputs
+	d.Set("display_name", converter.ToString(settings.DisplayName, ""))
+	d.Set("allowed_branches", inputs["allowedBranches"])
+	d.Set("verify_branch_protection", inputs["ensureProtectionOfBranch"] == "true")
+	d.Set("ignore_unknown_protection_status", inputs["allowUnknownStatusBranch"] == "true")
+	return nil
+}
@@ -0,0 +1,45 @@
+// +build all resource_check_branch_control
+// +build !exclude_resource_check_branch_control
+
+package pipelineschecks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+// verifies that the configuration of a branch control check survives an expand/flatten roundtrip
+func TestCheckBranchControl_ExpandFlatten_Roundtrip(t *testing.T) {
+	r := ResourceCheckBranchControl()
+	resourceData := getTestCheckResourceData(t, r, nil)
+	resourceData.Set("allowed_branches", "refs/heads/main,refs/heads/release/*")
+	resourceData.Set("verify_branch_protection", true)
+
+	flattenedData := roundtripCheck(t, r, resourceData, nil, expandBranchControlCheck, flattenBranchControlCheck)
+	require.Equal(t, "Branch control", flattenedData.Get("display_name"))
+	require.Equal(t, "refs/heads/main,refs/heads/release/*", flattenedData.Get("allowed_branches"))
+	require.Equal(t, true, flattenedData.Get("verify_branch_protection"))
+	require.Equal(t, false, flattenedData.Get("ignore_unknown_protection_status"))
+	require.Equal(t, 1440, flattenedData.Get("timeout"))
+}
+
+// verifies that a check evaluated by another task is not read as branch control check
+func TestCheckBranchControl_Flatten_RejectsOtherTask(t *testing.T) {
+	settings, err := json.Marshal(&taskCheckSettings{
+		DefinitionRef: &businessHoursTaskDefinition,
+	})
+	require.Nil(t, err)
+
+	r := ResourceCheckBranchControl()
+	resourceData := getTestCheckResourceData(t, r, nil)
+	err = flattenBranchControlCheck(resourceData, nil, &checkConfiguration{
+		Id:       converter.Int(7),
+		Type:     &taskCheckType,
+		Settings: settings,
+	})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "evaluatebranchProtection")
+}
@@ -0,0 +1,106 @@
+package pipelineschecks
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+var businessHoursTaskDefinition = taskCheckDefinitionReference{
+	Id:      converter.String("445fde2f-6c39-441c-807f-8a59ff2e075f"),
+	Name:    converter.String("evaluateBusinessHours"),
+	Version: converter.String("0.0.1"),
+}
+
+var businessDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+var validateTimeOfDay = validation.StringMatch(regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`), "must be a time of day formatted as hh:mm")
+
+// ResourceCheckBusinessHours schema and implementation for the business hours check of a protected resource
+func ResourceCheckBusinessHours() *schema.Resource {
+	r := genBaseCheckResource(flattenBusinessHoursCheck, expandBusinessHoursCheck)
+	r.Schema["timeout"].Default = 1440
+	r.Schema["display_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "Business hours",
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+	r.Schema["business_days"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(businessDays, false),
+		},
+	}
+	r.Schema["time_zone"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+	r.Schema["start_time"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validateTimeOfDay,
+	}
+	r.Schema["end_time"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validateTimeOfDay,
+	}
+	return r
+}
+
+func expandBusinessHoursCheck(d *schema.ResourceData, clients *client.AggregatedClient) (*checkType, interface{}, error) {
+	// the service expects the days in the order of the week, capitalized like "Monday,Friday"
+	configuredDays := tfhelper.ExpandStringSet(d.Get("business_days").(*schema.Set))
+	var days []string
+	for _, day := range businessDays {
+		for _, configuredDay := range configuredDays {
+			if configuredDay == day {
+				days = append(days, strings.Title(day))
+			}
+		}
+	}
+
+	return &taskCheckType, &taskCheckSettings{
+		DefinitionRef: &businessHoursTaskDefinition,
+		DisplayName:   converter.String(d.Get("display_name").(string)),
+		Inputs: &map[string]string{
+			"businessDays": strings.Join(days, ","),
+			"timeZone":     d.Get("time_zone").(string),
+			"startTime":    d.Get("start_time").(string),
+			"endTime":      d.Get("end_time").(string),
+		},
+		RetryInterval: converter.Int(5),
+	}, nil
+}
+
+func flattenBusinessHoursCheck(d *schema.ResourceData, clients *client.AggregatedClient, configuration *checkConfiguration) error {
+	settings, err := decodeTaskCheckSettings(configuration, &businessHoursTaskDefinition)
+	if err != nil {
+		return err
+	}
+
+	inputs := *settings.Inputs
+	var days []interface{}
+	for _, day := range strings.Split(inputs["businessDays"], ",") {
+		if day = strings.ToLower(strings.TrimSpace(day)); day != "" {
+			days = append(days, day)
+		}
+	}
+
+	d.Set("display_name", converter.ToString(settings.DisplayName, ""))
+	d.Set("business_days", days)
+	d.Set("time_zone", inputs["timeZone"])
+	d.Set("start_time", inputs["startTime"])
+	d.Set("end_time", inputs["endTime"])
+	return nil
+}
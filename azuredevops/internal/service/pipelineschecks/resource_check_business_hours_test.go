@@ -0,0 +1,42 @@
+// +build all resource_check_business_hours
+// +build !exclude_resource_check_business_hours
+
+package pipelineschecks
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// verifies that the business days are sent in the order of the week and survive an expand/flatten roundtrip
+func TestCheckBusinessHours_ExpandFlatten_Roundtrip(t *testing.T) {
+	r := ResourceCheckBusinessHours()
+	resourceData := getTestCheckResourceData(t, r, nil)
+	resourceData.Set("business_days", []interface{}{"friday", "monday", "wednesday"})
+	resourceData.Set("time_zone", "UTC")
+	resourceData.Set("start_time", "08:00")
+	resourceData.Set("end_time", "17:30")
+
+	_, settings, err := expandBusinessHoursCheck(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, "Monday,Wednesday,Friday", (*settings.(*taskCheckSettings).Inputs)["businessDays"])
+
+	flattenedData := roundtripCheck(t, r, resourceData, nil, expandBusinessHoursCheck, flattenBusinessHoursCheck)
+	require.ElementsMatch(t, []interface{}{"monday", "wednesday", "friday"}, flattenedData.Get("business_days").(*schema.Set).List())
+	require.Equal(t, "UTC", flattenedData.Get("time_zone"))
+	require.Equal(t, "08:00", flattenedData.Get("start_time"))
+	require.Equal(t, "17:30", flattenedData.Get("end_time"))
+}
+
+func TestCheckBusinessHours_ValidateTimeOfDay(t *testing.T) {
+	for _, value := range []string{"00:00", "08:30", "23:59"} {
+		_, errors := validateTimeOfDay(value, "start_time")
+		require.Empty(t, errors, value)
+	}
+	for _, value := range []string{"8:30", "24:00", "12:60", "noon"} {
+		_, errors := validateTimeOfDay(value, "start_time")
+		require.NotEmpty(t, errors, value)
+	}
+}
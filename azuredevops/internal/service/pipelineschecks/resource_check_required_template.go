@@ -0,0 +1,95 @@
+package pipelineschecks
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+type requiredTemplateCheckSettings struct {
+	ExtendsChecks *[]requiredTemplate `json:"extendsChecks,omitempty"`
+}
+
+type requiredTemplate struct {
+	RepositoryType *string `json:"repositoryType,omitempty"`
+	RepositoryName *string `json:"repositoryName,omitempty"`
+	RepositoryRef  *string `json:"repositoryRef,omitempty"`
+	TemplatePath   *string `json:"templatePath,omitempty"`
+}
+
+// ResourceCheckRequiredTemplate schema and implementation for the required template check of a protected resource
+func ResourceCheckRequiredTemplate() *schema.Resource {
+	r := genBaseCheckResource(flattenRequiredTemplateCheck, expandRequiredTemplateCheck)
+	r.Schema["required_template"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"repository_type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "git",
+					ValidateFunc: validation.StringInSlice([]string{"git", "github", "bitbucket"}, false),
+				},
+				"repository_name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"repository_ref": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"template_path": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+		},
+	}
+	return r
+}
+
+func expandRequiredTemplateCheck(d *schema.ResourceData, clients *client.AggregatedClient) (*checkType, interface{}, error) {
+	templates := []requiredTemplate{}
+	for _, item := range d.Get("required_template").([]interface{}) {
+		template := item.(map[string]interface{})
+		templates = append(templates, requiredTemplate{
+			RepositoryType: converter.String(template["repository_type"].(string)),
+			RepositoryName: converter.String(template["repository_name"].(string)),
+			RepositoryRef:  converter.String(template["repository_ref"].(string)),
+			TemplatePath:   converter.String(template["template_path"].(string)),
+		})
+	}
+
+	return &requiredTemplateCheckType, &requiredTemplateCheckSettings{
+		ExtendsChecks: &templates,
+	}, nil
+}
+
+func flattenRequiredTemplateCheck(d *schema.ResourceData, clients *client.AggregatedClient, configuration *checkConfiguration) error {
+	var settings requiredTemplateCheckSettings
+	if err := json.Unmarshal(configuration.Settings, &settings); err != nil {
+		return err
+	}
+
+	templates := []interface{}{}
+	if settings.ExtendsChecks != nil {
+		for _, template := range *settings.ExtendsChecks {
+			templates = append(templates, map[string]interface{}{
+				"repository_type": converter.ToString(template.RepositoryType, ""),
+				"repository_name": converter.ToString(template.RepositoryName, ""),
+				"repository_ref":  converter.ToString(template.RepositoryRef, ""),
+				"template_path":   converter.ToString(template.TemplatePath, ""),
+			})
+		}
+	}
+	d.Set("required_template", templates)
+	return nil
+}
@@ -0,0 +1,33 @@
+// +build all resource_check_required_template
+// +build !exclude_resource_check_required_template
+
+package pipelineschecks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// verifies that the required templates survive an expand/flatten roundtrip
+func TestCheckRequiredTemplate_ExpandFlatten_Roundtrip(t *testing.T) {
+	r := ResourceCheckRequiredTemplate()
+	resourceData := getTestCheckResourceData(t, r, nil)
+	resourceData.Set("required_template", []interface{}{
+		map[string]interface{}{
+			"repository_type": "git",
+			"repository_name": "project/templates",
+			"repository_ref":  "refs/heads/main",
+			"template_path":   "deploy.yml",
+		},
+		map[string]interface{}{
+			"repository_type": "github",
+			"repository_name": "contoso/templates",
+			"repository_ref":  "refs/heads/main",
+			"template_path":   "build.yml",
+		},
+	})
+
+	flattenedData := roundtripCheck(t, r, resourceData, nil, expandRequiredTemplateCheck, flattenRequiredTemplateCheck)
+	require.Equal(t, resourceData.Get("required_template"), flattenedData.Get("required_template"))
+}
@@ -0,0 +1,145 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/release"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/validate"
+)
+
+// DataReleaseDefinition schema and implementation for release definition data source
+func DataReleaseDefinition() *schema.Resource {
+	// The data source exposes all attributes of the resource, the arguments
+	// identifying the release definition are overridden below.
+	dataSchema := tfhelper.ComputedSchema(ResourceReleaseDefinition().Schema)
+	dataSchema["project_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.NoZeroValues,
+	}
+	dataSchema["release_definition_id"] = &schema.Schema{
+		Type:          schema.TypeInt,
+		Optional:      true,
+		Computed:      true,
+		ValidateFunc:  validation.IntAtLeast(1),
+		ConflictsWith: []string{"name"},
+	}
+	dataSchema["name"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ValidateFunc:  validation.StringIsNotWhiteSpace,
+		ConflictsWith: []string{"release_definition_id"},
+	}
+	dataSchema["path"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validate.Path,
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceReleaseDefinitionRead,
+		Schema: dataSchema,
+	}
+}
+
+func dataSourceReleaseDefinitionRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	definitionID := d.Get("release_definition_id").(int)
+	if definitionID == 0 {
+		name := d.Get("name").(string)
+		if name == "" {
+			return fmt.Errorf("Either the release_definition_id or the name of the release definition must be configured")
+		}
+
+		var err error
+		definitionID, err = findReleaseDefinitionIDByNameAndPath(clients, projectID, name, d.Get("path").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	releaseDefinition, err := clients.ReleaseClient.GetReleaseDefinition(clients.Ctx, release.GetReleaseDefinitionArgs{
+		Project:      converter.String(projectID),
+		DefinitionId: converter.Int(definitionID),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return fmt.Errorf("Release definition with ID %d does not exist in project %s", definitionID, projectID)
+		}
+		return fmt.Errorf("Error reading release definition with ID %d: %+v", definitionID, err)
+	}
+	if releaseDefinition.IsDeleted != nil && *releaseDefinition.IsDeleted {
+		return fmt.Errorf("Release definition with ID %d does not exist in project %s", definitionID, projectID)
+	}
+
+	if err := flattenReleaseDefinition(d, releaseDefinition, projectID); err != nil {
+		return err
+	}
+	d.Set("release_definition_id", definitionID)
+	return nil
+}
+
+// findReleaseDefinitionIDByNameAndPath returns the ID of the release definition with the given name. If no path is
+// configured the name must be unique within the project.
+func findReleaseDefinitionIDByNameAndPath(clients *client.AggregatedClient, projectID string, name string, path string) (int, error) {
+	args := release.GetReleaseDefinitionsArgs{
+		Project:          converter.String(projectID),
+		SearchText:       converter.String(name),
+		IsExactNameMatch: converter.Bool(true),
+	}
+	if path != "" {
+		args.Path = converter.String(path)
+	}
+	definitions, err := getReleaseDefinitions(clients, args)
+	if err != nil {
+		return 0, fmt.Errorf("Error finding release definition with name %s in project %s: %+v", name, projectID, err)
+	}
+
+	var matches []release.ReleaseDefinition
+	for _, definition := range definitions {
+		if !strings.EqualFold(converter.ToString(definition.Name, ""), name) {
+			continue
+		}
+		// the service also returns the definitions in the subfolders of the path
+		if path != "" && !strings.EqualFold(converter.ToString(definition.Path, ""), path) {
+			continue
+		}
+		matches = append(matches, definition)
+	}
+
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("Release definition with name %s does not exist in project %s", name, projectID)
+	}
+	if len(matches) > 1 {
+		return 0, fmt.Errorf("Multiple release definitions with name %s found in project %s, please specify the path", name, projectID)
+	}
+	return *matches[0].Id, nil
+}
+
+// getReleaseDefinitions returns all definitions matching the query, following the continuation tokens
+func getReleaseDefinitions(clients *client.AggregatedClient, args release.GetReleaseDefinitionsArgs) ([]release.ReleaseDefinition, error) {
+	var definitions []release.ReleaseDefinition
+	for {
+		response, err := clients.ReleaseClient.GetReleaseDefinitions(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, response.Value...)
+
+		if response.ContinuationToken == "" {
+			return definitions, nil
+		}
+		args.ContinuationToken = converter.String(response.ContinuationToken)
+	}
+}
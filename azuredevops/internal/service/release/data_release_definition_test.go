@@ -0,0 +1,193 @@
+// +build all data_sources data_release_definition
+// +build !exclude_data_sources !exclude_data_release_definition
+
+package release
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/release"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testDataReleaseDefinitionProjectID = uuid.New().String()
+
+var testReleaseDefinitions = []release.ReleaseDefinition{
+	{
+		Id:   converter.Int(1),
+		Name: converter.String("Name"),
+		Path: converter.String(`\TeamA`),
+	},
+	{
+		Id:   converter.Int(2),
+		Name: converter.String("Name"),
+		Path: converter.String(`\TeamB`),
+		Environments: &[]release.ReleaseDefinitionEnvironment{
+			{Id: converter.Int(12), Name: converter.String("Prod"), Rank: converter.Int(2)},
+			{Id: converter.Int(11), Name: converter.String("Dev"), Rank: converter.Int(1)},
+		},
+		Artifacts: &[]release.Artifact{
+			{
+				Alias:     converter.String("_build"),
+				Type:      converter.String("Build"),
+				IsPrimary: converter.Bool(true),
+				DefinitionReference: &map[string]release.ArtifactSourceReference{
+					"definition": {Id: converter.String("7"), Name: converter.String("Build")},
+				},
+			},
+		},
+	},
+	{
+		Id:   converter.Int(3),
+		Name: converter.String("Name"),
+		Path: converter.String(`\TeamB\Nightly`),
+	},
+}
+
+func mockGetReleaseDefinitionsByName(releaseClient *azdosdkmocks.MockReleaseClient, ctx context.Context, path *string) {
+	releaseClient.
+		EXPECT().
+		GetReleaseDefinitions(ctx, release.GetReleaseDefinitionsArgs{
+			Project:          &testDataReleaseDefinitionProjectID,
+			SearchText:       converter.String("Name"),
+			IsExactNameMatch: converter.Bool(true),
+			Path:             path,
+		}).
+		Return(&release.GetReleaseDefinitionsResponseValue{
+			Value: testReleaseDefinitions,
+		}, nil).
+		Times(1)
+}
+
+// verifies that the release definition is looked up by name and path and that its attributes are exposed
+func TestDataSourceReleaseDefinition_Read_FindsDefinitionByNameAndPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, DataReleaseDefinition().Schema, nil)
+	resourceData.Set("project_id", testDataReleaseDefinitionProjectID)
+	resourceData.Set("name", "Name")
+	resourceData.Set("path", `\teamb`)
+
+	mockGetReleaseDefinitionsByName(releaseClient, clients.Ctx, converter.String(`\teamb`))
+
+	foundDefinition := testReleaseDefinitions[1]
+	releaseClient.
+		EXPECT().
+		GetReleaseDefinition(clients.Ctx, release.GetReleaseDefinitionArgs{
+			Project:      &testDataReleaseDefinitionProjectID,
+			DefinitionId: converter.Int(2),
+		}).
+		Return(&foundDefinition, nil).
+		Times(1)
+
+	err := dataSourceReleaseDefinitionRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "2", resourceData.Id())
+	require.Equal(t, 2, resourceData.Get("release_definition_id"))
+	require.Equal(t, "Dev", resourceData.Get("stage.0.name"))
+	require.Equal(t, "7", resourceData.Get("artifact.0.definition_reference.definition"))
+}
+
+// verifies that an ambiguous name is reported instead of picking an arbitrary release definition
+func TestDataSourceReleaseDefinition_Read_FailsIfNameIsAmbiguous(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, DataReleaseDefinition().Schema, nil)
+	resourceData.Set("project_id", testDataReleaseDefinitionProjectID)
+	resourceData.Set("name", "Name")
+
+	mockGetReleaseDefinitionsByName(releaseClient, clients.Ctx, nil)
+	releaseClient.
+		EXPECT().
+		GetReleaseDefinition(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := dataSourceReleaseDefinitionRead(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Multiple release definitions with name Name found")
+}
+
+// verifies that a deleted release definition is reported as missing
+func TestDataSourceReleaseDefinition_Read_FailsIfDefinitionIsDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, DataReleaseDefinition().Schema, nil)
+	resourceData.Set("project_id", testDataReleaseDefinitionProjectID)
+	resourceData.Set("release_definition_id", 1)
+
+	deletedDefinition := testReleaseDefinitions[0]
+	deletedDefinition.IsDeleted = converter.Bool(true)
+	releaseClient.
+		EXPECT().
+		GetReleaseDefinition(clients.Ctx, gomock.Any()).
+		Return(&deletedDefinition, nil).
+		Times(1)
+
+	err := dataSourceReleaseDefinitionRead(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "does not exist")
+}
+
+// verifies that all pages of release definitions are returned with their stages and artifacts
+func TestDataSourceReleaseDefinitions_Read_FollowsContinuationToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, DataReleaseDefinitions().Schema, nil)
+	resourceData.Set("project_id", testDataReleaseDefinitionProjectID)
+	resourceData.Set("path", `\TeamB`)
+
+	expand := release.ReleaseDefinitionExpands("environments,artifacts")
+	args := release.GetReleaseDefinitionsArgs{
+		Project: &testDataReleaseDefinitionProjectID,
+		Path:    converter.String(`\TeamB`),
+		Expand:  &expand,
+	}
+	releaseClient.
+		EXPECT().
+		GetReleaseDefinitions(clients.Ctx, args).
+		Return(&release.GetReleaseDefinitionsResponseValue{
+			Value:             testReleaseDefinitions[1:2],
+			ContinuationToken: "next",
+		}, nil).
+		Times(1)
+	args.ContinuationToken = converter.String("next")
+	releaseClient.
+		EXPECT().
+		GetReleaseDefinitions(clients.Ctx, args).
+		Return(&release.GetReleaseDefinitionsResponseValue{
+			Value: testReleaseDefinitions[2:],
+		}, nil).
+		Times(1)
+
+	err := dataSourceReleaseDefinitionsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, 2, resourceData.Get("definitions.#"))
+	require.Equal(t, 2, resourceData.Get("definitions.0.id"))
+	require.Equal(t, 11, resourceData.Get("definitions.0.stages.0.id"))
+	require.Equal(t, "Prod", resourceData.Get("definitions.0.stages.1.name"))
+	require.Equal(t, "7", resourceData.Get("definitions.0.artifacts.0.definition_reference.definition"))
+	require.Equal(t, `\TeamB\Nightly`, resourceData.Get("definitions.1.path"))
+}
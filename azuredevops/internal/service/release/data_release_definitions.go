@@ -0,0 +1,238 @@
+package release
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/release"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/validate"
+)
+
+// DataReleaseDefinitions schema and implementation for release definitions data source
+func DataReleaseDefinitions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceReleaseDefinitionsRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.Path,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"artifact_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"definitions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"revision": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"stages": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"rank": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"artifacts": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"alias": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"source_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"is_primary": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"definition_reference": {
+										Type:     schema.TypeMap,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReleaseDefinitionsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	path := d.Get("path").(string)
+	name := d.Get("name").(string)
+	artifactType := d.Get("artifact_type").(string)
+
+	expand := release.ReleaseDefinitionExpands(strings.Join([]string{
+		string(release.ReleaseDefinitionExpandsValues.Environments),
+		string(release.ReleaseDefinitionExpandsValues.Artifacts),
+	}, ","))
+	args := release.GetReleaseDefinitionsArgs{
+		Project: converter.String(projectID),
+		Expand:  &expand,
+	}
+	if path != "" {
+		args.Path = converter.String(path)
+	}
+	if name != "" {
+		args.SearchText = converter.String(name)
+		args.IsExactNameMatch = converter.Bool(true)
+	}
+	if artifactType != "" {
+		args.ArtifactType = converter.String(artifactType)
+	}
+
+	definitions, err := getReleaseDefinitions(clients, args)
+	if err != nil {
+		return fmt.Errorf("Error finding release definitions in project %s. Error: %v", projectID, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] release definitions from project %s", len(definitions), projectID)
+
+	h := sha1.New()
+	if _, err := h.Write([]byte(strings.Join([]string{projectID, path, name, artifactType}, "#"))); err != nil {
+		return fmt.Errorf("Unable to compute hash for release definitions filter: %v", err)
+	}
+	d.SetId("definitions#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+
+	err = d.Set("definitions", flattenReleaseDefinitionReferences(definitions))
+	if err != nil {
+		return fmt.Errorf("Error setting release definitions of project %s: %v", projectID, err)
+	}
+	return nil
+}
+
+func flattenReleaseDefinitionReferences(definitions []release.ReleaseDefinition) []interface{} {
+	results := make([]interface{}, 0, len(definitions))
+	for _, definition := range definitions {
+		output := map[string]interface{}{
+			"name":      converter.ToString(definition.Name, ""),
+			"path":      converter.ToString(definition.Path, ""),
+			"url":       converter.ToString(definition.Url, ""),
+			"stages":    flattenReleaseDefinitionStageReferences(definition.Environments),
+			"artifacts": flattenReleaseDefinitionArtifactReferences(definition.Artifacts),
+		}
+		if definition.Id != nil {
+			output["id"] = *definition.Id
+		}
+		if definition.Revision != nil {
+			output["revision"] = *definition.Revision
+		}
+		results = append(results, output)
+	}
+	return results
+}
+
+func flattenReleaseDefinitionStageReferences(environments *[]release.ReleaseDefinitionEnvironment) []interface{} {
+	if environments == nil {
+		return nil
+	}
+
+	sorted := make([]release.ReleaseDefinitionEnvironment, len(*environments))
+	copy(sorted, *environments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return converter.ToInt(sorted[i].Rank, 0) < converter.ToInt(sorted[j].Rank, 0)
+	})
+
+	results := make([]interface{}, 0, len(sorted))
+	for _, environment := range sorted {
+		results = append(results, map[string]interface{}{
+			"id":   converter.ToInt(environment.Id, 0),
+			"name": converter.ToString(environment.Name, ""),
+			"rank": converter.ToInt(environment.Rank, 0),
+		})
+	}
+	return results
+}
+
+func flattenReleaseDefinitionArtifactReferences(artifacts *[]release.Artifact) []interface{} {
+	if artifacts == nil {
+		return nil
+	}
+
+	results := make([]interface{}, 0, len(*artifacts))
+	for _, artifact := range *artifacts {
+		references := map[string]interface{}{}
+		if artifact.DefinitionReference != nil {
+			for key, reference := range *artifact.DefinitionReference {
+				references[key] = converter.ToString(reference.Id, "")
+			}
+		}
+
+		results = append(results, map[string]interface{}{
+			"alias":                converter.ToString(artifact.Alias, ""),
+			"type":                 converter.ToString(artifact.Type, ""),
+			"source_id":            converter.ToString(artifact.SourceId, ""),
+			"is_primary":           converter.ToBool(artifact.IsPrimary, false),
+			"definition_reference": references,
+		})
+	}
+	return results
+}
@@ -0,0 +1,1550 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/release"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/validate"
+)
+
+const (
+	rdArtifact              = "artifact"
+	rdStage                 = "stage"
+	rdDeployPhase           = "deploy_phase"
+	rdTask                  = "task"
+	rdVariable              = "variable"
+	rdVariableName          = "name"
+	rdVariableValue         = "value"
+	rdSecretVariableValue   = "secret_value"
+	rdVariableIsSecret      = "is_secret"
+	rdVariableAllowOverride = "allow_override"
+)
+
+// The days of a release schedule in the order of the bits of the ScheduleDays flags enum
+var releaseScheduleDays = []release.ScheduleDays{
+	release.ScheduleDaysValues.Monday,
+	release.ScheduleDaysValues.Tuesday,
+	release.ScheduleDaysValues.Wednesday,
+	release.ScheduleDaysValues.Thursday,
+	release.ScheduleDaysValues.Friday,
+	release.ScheduleDaysValues.Saturday,
+	release.ScheduleDaysValues.Sunday,
+}
+
+// ResourceReleaseDefinition schema and implementation for release definition resource
+func ResourceReleaseDefinition() *schema.Resource {
+	scheduleDayNames := make([]string, len(releaseScheduleDays))
+	for i, day := range releaseScheduleDays {
+		scheduleDayNames[i] = string(day)
+	}
+
+	return &schema.Resource{
+		Create:   resourceReleaseDefinitionCreate,
+		Read:     resourceReleaseDefinitionRead,
+		Update:   resourceReleaseDefinitionUpdate,
+		Delete:   resourceReleaseDefinitionDelete,
+		Importer: tfhelper.ImportProjectQualifiedResource(),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"revision": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      `\`,
+				ValidateFunc: validate.Path,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"release_name_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Release-$(rev:r)",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"variable_groups": variableGroupsSchema(),
+			rdVariable:        variablesSchema(),
+			rdArtifact: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alias": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"definition_reference": {
+							Type:     schema.TypeMap,
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"is_primary": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"is_retained": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"artifact_trigger": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"artifact_alias": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"condition": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"source_branch": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"tags": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.StringIsNotWhiteSpace,
+										},
+									},
+									"use_build_definition_branch": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"create_release_on_build_tagging": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"schedule_trigger": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days_to_release": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(scheduleDayNames, false),
+							},
+						},
+						"start_hours": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 23),
+						},
+						"start_minutes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 59),
+						},
+						"time_zone": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "UTC",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"schedule_only_with_changes": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			rdStage: {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"owner_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+						"condition": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(release.ConditionTypeValues.Event),
+											string(release.ConditionTypeValues.EnvironmentState),
+											string(release.ConditionTypeValues.Artifact),
+										}, false),
+									},
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotWhiteSpace,
+									},
+									"value": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "",
+									},
+								},
+							},
+						},
+						"variable_groups":      variableGroupsSchema(),
+						rdVariable:             variablesSchema(),
+						"pre_deploy_approval":  approvalSchema(),
+						"post_deploy_approval": approvalSchema(),
+						"pre_deploy_gate":      gateSchema(),
+						"post_deploy_gate":     gateSchema(),
+						"retention_policy": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"days_to_keep": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      30,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"releases_to_keep": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      3,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"retain_build": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  true,
+									},
+								},
+							},
+						},
+						rdDeployPhase: {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotWhiteSpace,
+									},
+									"ref_name": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  string(release.DeployPhaseTypesValues.AgentBasedDeployment),
+										ValidateFunc: validation.StringInSlice([]string{
+											string(release.DeployPhaseTypesValues.AgentBasedDeployment),
+											string(release.DeployPhaseTypesValues.RunOnServer),
+										}, false),
+									},
+									"queue_id": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"agent_specification": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringIsNotWhiteSpace,
+									},
+									"demands": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.StringIsNotWhiteSpace,
+										},
+									},
+									"condition": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "succeeded()",
+										ValidateFunc: validation.StringIsNotWhiteSpace,
+									},
+									"timeout_in_minutes": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      0,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"job_cancel_timeout_in_minutes": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      1,
+										ValidateFunc: validation.IntBetween(1, 60),
+									},
+									"skip_artifacts_download": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"enable_access_token": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									rdTask: taskSchema(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func variableGroupsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeInt,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+	}
+}
+
+func variablesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				rdVariableName: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				rdVariableValue: {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "",
+				},
+				rdSecretVariableValue: {
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+					Default:   "",
+				},
+				rdVariableIsSecret: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				rdVariableAllowOverride: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+func taskSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"task_id": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.IsUUID,
+				},
+				"version": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"definition_type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "task",
+					ValidateFunc: validation.StringInSlice([]string{"task", "metaTask"}, false),
+				},
+				"display_name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"ref_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"enabled": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"continue_on_error": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"always_run": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"condition": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "succeeded()",
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"timeout_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"inputs": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"environment": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func approvalSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"approver_ids": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsUUID,
+					},
+				},
+				"required_approver_count": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"release_creator_can_be_approver": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"skip_if_previously_approved": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"enforce_identity_revalidation": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"timeout_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      43200,
+					ValidateFunc: validation.IntBetween(1, 525600),
+				},
+				"execution_order": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  string(release.ApprovalExecutionOrderValues.BeforeGates),
+					ValidateFunc: validation.StringInSlice([]string{
+						string(release.ApprovalExecutionOrderValues.BeforeGates),
+						string(release.ApprovalExecutionOrderValues.AfterSuccessfulGates),
+						string(release.ApprovalExecutionOrderValues.AfterGatesAlways),
+					}, false),
+				},
+			},
+		},
+	}
+}
+
+func gateSchema() *schema.Schema {
+	gateTaskSchema := taskSchema()
+	gateTaskSchema.Required = true
+	gateTaskSchema.Optional = false
+	gateTaskSchema.MinItems = 1
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"timeout_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1440,
+					ValidateFunc: validation.IntBetween(6, 21600),
+				},
+				"sampling_interval_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      15,
+					ValidateFunc: validation.IntBetween(5, 1440),
+				},
+				"stabilization_time_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      5,
+					ValidateFunc: validation.IntBetween(0, 2880),
+				},
+				"minimum_success_duration_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntBetween(0, 1440),
+				},
+				rdTask: gateTaskSchema,
+			},
+		},
+	}
+}
+
+func resourceReleaseDefinitionCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	releaseDefinition, projectID, err := expandReleaseDefinition(d)
+	if err != nil {
+		return fmt.Errorf("Error expanding release definition: %+v", err)
+	}
+
+	createdReleaseDefinition, err := clients.ReleaseClient.CreateReleaseDefinition(clients.Ctx, release.CreateReleaseDefinitionArgs{
+		ReleaseDefinition: releaseDefinition,
+		Project:           converter.String(projectID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating release definition %s in project %s: %+v", *releaseDefinition.Name, projectID, err)
+	}
+
+	d.SetId(strconv.Itoa(*createdReleaseDefinition.Id))
+	return resourceReleaseDefinitionRead(d, m)
+}
+
+func resourceReleaseDefinitionRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, releaseDefinitionID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	releaseDefinition, err := clients.ReleaseClient.GetReleaseDefinition(clients.Ctx, release.GetReleaseDefinitionArgs{
+		Project:      converter.String(projectID),
+		DefinitionId: converter.Int(releaseDefinitionID),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading release definition %d in project %s: %+v", releaseDefinitionID, projectID, err)
+	}
+	if releaseDefinition == nil || converter.ToBool(releaseDefinition.IsDeleted, false) {
+		d.SetId("")
+		return nil
+	}
+
+	return flattenReleaseDefinition(d, releaseDefinition, projectID)
+}
+
+func resourceReleaseDefinitionUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	releaseDefinition, projectID, err := expandReleaseDefinition(d)
+	if err != nil {
+		return fmt.Errorf("Error expanding release definition: %+v", err)
+	}
+
+	// The service rejects the update with a conflict if the revision does not match the latest
+	// revision of the definition, which protects changes made outside of Terraform since the last refresh.
+	_, err = clients.ReleaseClient.UpdateReleaseDefinition(clients.Ctx, release.UpdateReleaseDefinitionArgs{
+		ReleaseDefinition: releaseDefinition,
+		Project:           converter.String(projectID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating release definition %s (revision %d) in project %s: %+v", d.Id(), *releaseDefinition.Revision, projectID, err)
+	}
+
+	return resourceReleaseDefinitionRead(d, m)
+}
+
+func resourceReleaseDefinitionDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, releaseDefinitionID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	err = clients.ReleaseClient.DeleteReleaseDefinition(clients.Ctx, release.DeleteReleaseDefinitionArgs{
+		Project:      converter.String(projectID),
+		DefinitionId: converter.Int(releaseDefinitionID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting release definition %d in project %s: %+v", releaseDefinitionID, projectID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandReleaseDefinition(d *schema.ResourceData) (*release.ReleaseDefinition, string, error) {
+	projectID := d.Get("project_id").(string)
+
+	variables, err := expandReleaseDefinitionVariables(d.Get(rdVariable).(*schema.Set).List())
+	if err != nil {
+		return nil, "", err
+	}
+	environments, err := expandReleaseDefinitionEnvironments(d)
+	if err != nil {
+		return nil, "", err
+	}
+
+	releaseDefinition := release.ReleaseDefinition{
+		Name:              converter.String(d.Get("name").(string)),
+		Path:              converter.String(d.Get("path").(string)),
+		Description:       converter.String(d.Get("description").(string)),
+		ReleaseNameFormat: converter.String(d.Get("release_name_format").(string)),
+		Tags:              expandStringSet(d.Get("tags").(*schema.Set)),
+		VariableGroups:    expandVariableGroups(d.Get("variable_groups").(*schema.Set)),
+		Variables:         variables,
+		Artifacts:         expandReleaseDefinitionArtifacts(d.Get(rdArtifact).([]interface{})),
+		Triggers:          expandReleaseDefinitionTriggers(d),
+		Environments:      environments,
+	}
+
+	if d.Id() != "" {
+		releaseDefinitionID, err := strconv.Atoi(d.Id())
+		if err != nil {
+			return nil, "", fmt.Errorf("Error parsing release definition ID %s: %+v", d.Id(), err)
+		}
+		releaseDefinition.Id = converter.Int(releaseDefinitionID)
+		releaseDefinition.Revision = converter.Int(d.Get("revision").(int))
+	}
+	return &releaseDefinition, projectID, nil
+}
+
+func flattenReleaseDefinition(d *schema.ResourceData, releaseDefinition *release.ReleaseDefinition, projectID string) error {
+	artifactTriggers, scheduleTriggers, err := flattenReleaseDefinitionTriggers(releaseDefinition.Triggers)
+	if err != nil {
+		return fmt.Errorf("Error flattening triggers of release definition %s: %+v", d.Id(), err)
+	}
+	stages, err := flattenReleaseDefinitionEnvironments(d, releaseDefinition.Environments)
+	if err != nil {
+		return fmt.Errorf("Error flattening stages of release definition %s: %+v", d.Id(), err)
+	}
+
+	d.SetId(strconv.Itoa(*releaseDefinition.Id))
+	d.Set("project_id", projectID)
+	d.Set("revision", converter.ToInt(releaseDefinition.Revision, 0))
+	d.Set("name", converter.ToString(releaseDefinition.Name, ""))
+	d.Set("path", converter.ToString(releaseDefinition.Path, `\`))
+	d.Set("description", converter.ToString(releaseDefinition.Description, ""))
+	d.Set("release_name_format", converter.ToString(releaseDefinition.ReleaseNameFormat, ""))
+	d.Set("tags", flattenStringList(releaseDefinition.Tags))
+	d.Set("variable_groups", flattenIntList(releaseDefinition.VariableGroups))
+	d.Set(rdVariable, flattenReleaseDefinitionVariables(d, rdVariable, releaseDefinition.Variables))
+	d.Set(rdArtifact, flattenReleaseDefinitionArtifacts(d, releaseDefinition.Artifacts))
+	d.Set("artifact_trigger", artifactTriggers)
+	d.Set("schedule_trigger", scheduleTriggers)
+	d.Set(rdStage, stages)
+	return nil
+}
+
+func expandReleaseDefinitionVariables(variables []interface{}) (*map[string]release.ConfigurationVariableValue, error) {
+	expandedVariables := map[string]release.ConfigurationVariableValue{}
+	for _, variable := range variables {
+		variableMap := variable.(map[string]interface{})
+		name := variableMap[rdVariableName].(string)
+
+		if _, ok := expandedVariables[name]; ok {
+			return nil, fmt.Errorf("Unexpectedly found duplicate variable with name %s", name)
+		}
+
+		isSecret := variableMap[rdVariableIsSecret].(bool)
+		value := variableMap[rdVariableValue].(string)
+		if isSecret {
+			value = variableMap[rdSecretVariableValue].(string)
+		}
+		expandedVariables[name] = release.ConfigurationVariableValue{
+			AllowOverride: converter.Bool(variableMap[rdVariableAllowOverride].(bool)),
+			IsSecret:      converter.Bool(isSecret),
+			Value:         converter.String(value),
+		}
+	}
+	return &expandedVariables, nil
+}
+
+// flattenReleaseDefinitionVariables keeps the values of secret variables from the state at the given
+// key, because the service does not return them.
+func flattenReleaseDefinitionVariables(d *schema.ResourceData, key string, variables *map[string]release.ConfigurationVariableValue) []interface{} {
+	if variables == nil {
+		return nil
+	}
+
+	results := make([]interface{}, 0, len(*variables))
+	for name, value := range *variables {
+		isSecret := converter.ToBool(value.IsSecret, false)
+		variable := map[string]interface{}{
+			rdVariableName:          name,
+			rdVariableValue:         converter.ToString(value.Value, ""),
+			rdVariableIsSecret:      isSecret,
+			rdVariableAllowOverride: converter.ToBool(value.AllowOverride, false),
+		}
+
+		if isSecret {
+			variable[rdVariableValue] = ""
+			if stateVariable := tfhelper.FindMapInSetWithGivenKeyValue(d, key, rdVariableName, name); stateVariable != nil {
+				variable = stateVariable
+			}
+		}
+		results = append(results, variable)
+	}
+	return results
+}
+
+func expandReleaseDefinitionArtifacts(artifacts []interface{}) *[]release.Artifact {
+	results := make([]release.Artifact, 0, len(artifacts))
+	hasPrimary := false
+	for _, artifact := range artifacts {
+		artifactMap := artifact.(map[string]interface{})
+
+		references := map[string]release.ArtifactSourceReference{}
+		for key, value := range artifactMap["definition_reference"].(map[string]interface{}) {
+			references[key] = release.ArtifactSourceReference{
+				Id: converter.String(fmt.Sprint(value)),
+			}
+		}
+
+		isPrimary := artifactMap["is_primary"].(bool) && !hasPrimary
+		hasPrimary = hasPrimary || isPrimary
+		results = append(results, release.Artifact{
+			Alias:               converter.String(artifactMap["alias"].(string)),
+			Type:                converter.String(artifactMap["type"].(string)),
+			DefinitionReference: &references,
+			IsPrimary:           converter.Bool(isPrimary),
+			IsRetained:          converter.Bool(artifactMap["is_retained"].(bool)),
+		})
+	}
+
+	// The service requires exactly one primary artifact, which defaults to the first one.
+	if !hasPrimary && len(results) > 0 {
+		results[0].IsPrimary = converter.Bool(true)
+	}
+	return &results
+}
+
+func flattenReleaseDefinitionArtifacts(d *schema.ResourceData, artifacts *[]release.Artifact) []interface{} {
+	if artifacts == nil {
+		return nil
+	}
+
+	results := make([]interface{}, 0, len(*artifacts))
+	for i, artifact := range *artifacts {
+		// The service adds references like the names of the project and the definition or the URL of
+		// the artifact source. Only the references which are part of the state are kept, unless there
+		// is no state, e.g. during an import.
+		stateReferences, _ := d.Get(fmt.Sprintf("%s.%d.definition_reference", rdArtifact, i)).(map[string]interface{})
+		references := map[string]interface{}{}
+		if artifact.DefinitionReference != nil {
+			for key, reference := range *artifact.DefinitionReference {
+				if _, ok := stateReferences[key]; ok || len(stateReferences) == 0 {
+					references[key] = converter.ToString(reference.Id, "")
+				}
+			}
+		}
+
+		results = append(results, map[string]interface{}{
+			"alias":                converter.ToString(artifact.Alias, ""),
+			"type":                 converter.ToString(artifact.Type, ""),
+			"definition_reference": references,
+			"is_primary":           converter.ToBool(artifact.IsPrimary, false),
+			"is_retained":          converter.ToBool(artifact.IsRetained, false),
+		})
+	}
+	return results
+}
+
+func expandReleaseDefinitionTriggers(d *schema.ResourceData) *[]interface{} {
+	triggers := []interface{}{}
+	for _, trigger := range d.Get("artifact_trigger").([]interface{}) {
+		triggerMap := trigger.(map[string]interface{})
+
+		conditions := []release.ArtifactFilter{}
+		for _, condition := range triggerMap["condition"].([]interface{}) {
+			conditionMap := condition.(map[string]interface{})
+			conditions = append(conditions, release.ArtifactFilter{
+				SourceBranch:                converter.String(conditionMap["source_branch"].(string)),
+				Tags:                        expandStringList(conditionMap["tags"].([]interface{})),
+				UseBuildDefinitionBranch:    converter.Bool(conditionMap["use_build_definition_branch"].(bool)),
+				CreateReleaseOnBuildTagging: converter.Bool(conditionMap["create_release_on_build_tagging"].(bool)),
+			})
+		}
+
+		triggerType := release.ReleaseTriggerTypeValues.ArtifactSource
+		triggers = append(triggers, release.ArtifactSourceTrigger{
+			TriggerType:       &triggerType,
+			ArtifactAlias:     converter.String(triggerMap["artifact_alias"].(string)),
+			TriggerConditions: &conditions,
+		})
+	}
+
+	for _, trigger := range d.Get("schedule_trigger").([]interface{}) {
+		triggerMap := trigger.(map[string]interface{})
+
+		triggerType := release.ReleaseTriggerTypeValues.Schedule
+		triggers = append(triggers, release.ScheduledReleaseTrigger{
+			TriggerType: &triggerType,
+			Schedule: &release.ReleaseSchedule{
+				DaysToRelease:           expandScheduleDays(triggerMap["days_to_release"].(*schema.Set)),
+				StartHours:              converter.Int(triggerMap["start_hours"].(int)),
+				StartMinutes:            converter.Int(triggerMap["start_minutes"].(int)),
+				TimeZoneId:              converter.String(triggerMap["time_zone"].(string)),
+				ScheduleOnlyWithChanges: converter.Bool(triggerMap["schedule_only_with_changes"].(bool)),
+			},
+		})
+	}
+	return &triggers
+}
+
+func flattenReleaseDefinitionTriggers(triggers *[]interface{}) ([]interface{}, []interface{}, error) {
+	artifactTriggers := []interface{}{}
+	scheduleTriggers := []interface{}{}
+	if triggers == nil {
+		return artifactTriggers, scheduleTriggers, nil
+	}
+
+	for _, trigger := range *triggers {
+		var triggerBase release.ReleaseTriggerBase
+		if err := decodeReleaseDefinitionItem(trigger, &triggerBase); err != nil {
+			return nil, nil, err
+		}
+
+		switch converter.ToString((*string)(triggerBase.TriggerType), "") {
+		case string(release.ReleaseTriggerTypeValues.ArtifactSource):
+			var artifactTrigger release.ArtifactSourceTrigger
+			if err := decodeReleaseDefinitionItem(trigger, &artifactTrigger); err != nil {
+				return nil, nil, err
+			}
+
+			conditions := []interface{}{}
+			if artifactTrigger.TriggerConditions != nil {
+				for _, condition := range *artifactTrigger.TriggerConditions {
+					conditions = append(conditions, map[string]interface{}{
+						"source_branch":                   converter.ToString(condition.SourceBranch, ""),
+						"tags":                            flattenStringList(condition.Tags),
+						"use_build_definition_branch":     converter.ToBool(condition.UseBuildDefinitionBranch, false),
+						"create_release_on_build_tagging": converter.ToBool(condition.CreateReleaseOnBuildTagging, false),
+					})
+				}
+			}
+			artifactTriggers = append(artifactTriggers, map[string]interface{}{
+				"artifact_alias": converter.ToString(artifactTrigger.ArtifactAlias, ""),
+				"condition":      conditions,
+			})
+		case string(release.ReleaseTriggerTypeValues.Schedule):
+			var scheduleTrigger release.ScheduledReleaseTrigger
+			if err := decodeReleaseDefinitionItem(trigger, &scheduleTrigger); err != nil {
+				return nil, nil, err
+			}
+			if scheduleTrigger.Schedule == nil {
+				continue
+			}
+
+			schedule := scheduleTrigger.Schedule
+			scheduleTriggers = append(scheduleTriggers, map[string]interface{}{
+				"days_to_release":            flattenScheduleDays(schedule.DaysToRelease),
+				"start_hours":                converter.ToInt(schedule.StartHours, 0),
+				"start_minutes":              converter.ToInt(schedule.StartMinutes, 0),
+				"time_zone":                  converter.ToString(schedule.TimeZoneId, ""),
+				"schedule_only_with_changes": converter.ToBool(schedule.ScheduleOnlyWithChanges, false),
+			})
+		}
+	}
+	return artifactTriggers, scheduleTriggers, nil
+}
+
+// expandScheduleDays returns the days as value of the ScheduleDays flags enum, e.g. "monday, friday"
+func expandScheduleDays(days *schema.Set) *release.ScheduleDays {
+	names := []string{}
+	for _, day := range releaseScheduleDays {
+		if days.Contains(string(day)) {
+			names = append(names, string(day))
+		}
+	}
+	scheduleDays := release.ScheduleDays(strings.Join(names, ", "))
+	return &scheduleDays
+}
+
+func flattenScheduleDays(scheduleDays *release.ScheduleDays) []interface{} {
+	days := []interface{}{}
+	if scheduleDays == nil {
+		return days
+	}
+
+	for _, name := range strings.Split(string(*scheduleDays), ",") {
+		name = strings.TrimSpace(name)
+		if strings.EqualFold(name, string(release.ScheduleDaysValues.All)) {
+			days = []interface{}{}
+			for _, day := range releaseScheduleDays {
+				days = append(days, string(day))
+			}
+			return days
+		}
+		for _, day := range releaseScheduleDays {
+			if strings.EqualFold(name, string(day)) {
+				days = append(days, string(day))
+			}
+		}
+	}
+	return days
+}
+
+// getReleaseDefinitionEnvironmentIDs returns the IDs of the stages in the state by their names. The
+// IDs are matched by name instead of by position, so that adding, removing or reordering other
+// stages does not recreate a stage and lose its deployment history.
+func getReleaseDefinitionEnvironmentIDs(d *schema.ResourceData) map[string]int {
+	environmentIDs := map[string]int{}
+	oldStages, _ := d.GetChange(rdStage)
+	for _, stage := range oldStages.([]interface{}) {
+		stageMap := stage.(map[string]interface{})
+		if id := stageMap["id"].(int); id > 0 {
+			environmentIDs[stageMap["name"].(string)] = id
+		}
+	}
+	return environmentIDs
+}
+
+func expandReleaseDefinitionEnvironments(d *schema.ResourceData) (*[]release.ReleaseDefinitionEnvironment, error) {
+	environmentIDs := getReleaseDefinitionEnvironmentIDs(d)
+	stages := d.Get(rdStage).([]interface{})
+	environments := make([]release.ReleaseDefinitionEnvironment, 0, len(stages))
+	for i, stage := range stages {
+		stageMap := stage.(map[string]interface{})
+		name := stageMap["name"].(string)
+
+		variables, err := expandReleaseDefinitionVariables(stageMap[rdVariable].(*schema.Set).List())
+		if err != nil {
+			return nil, fmt.Errorf("Error expanding variables of stage %s: %+v", name, err)
+		}
+		deployPhases, err := expandReleaseDefinitionDeployPhases(stageMap[rdDeployPhase].([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("Error expanding deploy phases of stage %s: %+v", name, err)
+		}
+
+		conditions := []release.Condition{}
+		for _, condition := range stageMap["condition"].([]interface{}) {
+			conditionMap := condition.(map[string]interface{})
+			conditionType := release.ConditionType(conditionMap["type"].(string))
+			conditions = append(conditions, release.Condition{
+				ConditionType: &conditionType,
+				Name:          converter.String(conditionMap["name"].(string)),
+				Value:         converter.String(conditionMap["value"].(string)),
+			})
+		}
+
+		environment := release.ReleaseDefinitionEnvironment{
+			Name:                converter.String(name),
+			Rank:                converter.Int(i + 1),
+			Owner:               &webapi.IdentityRef{Id: converter.String(stageMap["owner_id"].(string))},
+			Conditions:          &conditions,
+			VariableGroups:      expandVariableGroups(stageMap["variable_groups"].(*schema.Set)),
+			Variables:           variables,
+			PreDeployApprovals:  expandReleaseDefinitionApprovals(stageMap["pre_deploy_approval"].([]interface{})),
+			PostDeployApprovals: expandReleaseDefinitionApprovals(stageMap["post_deploy_approval"].([]interface{})),
+			PreDeploymentGates:  expandReleaseDefinitionGates(stageMap["pre_deploy_gate"].([]interface{})),
+			PostDeploymentGates: expandReleaseDefinitionGates(stageMap["post_deploy_gate"].([]interface{})),
+			RetentionPolicy:     expandReleaseDefinitionRetentionPolicy(stageMap["retention_policy"].([]interface{})),
+			DeployPhases:        deployPhases,
+			DeployStep:          &release.ReleaseDefinitionDeployStep{},
+			EnvironmentOptions: &release.EnvironmentOptions{
+				PublishDeploymentStatus: converter.Bool(true),
+			},
+			ExecutionPolicy: &release.EnvironmentExecutionPolicy{
+				ConcurrencyCount: converter.Int(1),
+				QueueDepthCount:  converter.Int(0),
+			},
+			Schedules: &[]release.ReleaseSchedule{},
+		}
+		if id, ok := environmentIDs[name]; ok {
+			environment.Id = converter.Int(id)
+		}
+		environments = append(environments, environment)
+	}
+	return &environments, nil
+}
+
+// flattenReleaseDefinitionEnvironments returns the stages ordered by their rank, which is the
+// order in which they are configured, independent of the order returned by the service.
+func flattenReleaseDefinitionEnvironments(d *schema.ResourceData, environments *[]release.ReleaseDefinitionEnvironment) ([]interface{}, error) {
+	if environments == nil {
+		return nil, nil
+	}
+
+	sortedEnvironments := make([]release.ReleaseDefinitionEnvironment, len(*environments))
+	copy(sortedEnvironments, *environments)
+	sort.SliceStable(sortedEnvironments, func(i, j int) bool {
+		return converter.ToInt(sortedEnvironments[i].Rank, 0) < converter.ToInt(sortedEnvironments[j].Rank, 0)
+	})
+
+	results := make([]interface{}, 0, len(sortedEnvironments))
+	for i, environment := range sortedEnvironments {
+		key := fmt.Sprintf("%s.%d", rdStage, i)
+
+		deployPhases, err := flattenReleaseDefinitionDeployPhases(d, key, environment.DeployPhases)
+		if err != nil {
+			return nil, err
+		}
+
+		conditions := []interface{}{}
+		if environment.Conditions != nil {
+			for _, condition := range *environment.Conditions {
+				conditions = append(conditions, map[string]interface{}{
+					"type":  converter.ToString((*string)(condition.ConditionType), ""),
+					"name":  converter.ToString(condition.Name, ""),
+					"value": converter.ToString(condition.Value, ""),
+				})
+			}
+		}
+
+		ownerID := ""
+		if environment.Owner != nil {
+			ownerID = converter.ToString(environment.Owner.Id, "")
+		}
+
+		results = append(results, map[string]interface{}{
+			"id":                   converter.ToInt(environment.Id, 0),
+			"name":                 converter.ToString(environment.Name, ""),
+			"owner_id":             ownerID,
+			"condition":            conditions,
+			"variable_groups":      flattenIntList(environment.VariableGroups),
+			rdVariable:             flattenReleaseDefinitionVariables(d, key+"."+rdVariable, environment.Variables),
+			"pre_deploy_approval":  flattenReleaseDefinitionApprovals(environment.PreDeployApprovals),
+			"post_deploy_approval": flattenReleaseDefinitionApprovals(environment.PostDeployApprovals),
+			"pre_deploy_gate":      flattenReleaseDefinitionGates(d, key+".pre_deploy_gate.0", environment.PreDeploymentGates),
+			"post_deploy_gate":     flattenReleaseDefinitionGates(d, key+".post_deploy_gate.0", environment.PostDeploymentGates),
+			"retention_policy":     flattenReleaseDefinitionRetentionPolicy(environment.RetentionPolicy),
+			rdDeployPhase:          deployPhases,
+		})
+	}
+	return results, nil
+}
+
+// expandReleaseDefinitionApprovals returns an automated approval if no approvers are configured,
+// because the service requires at least one approval step for every stage.
+func expandReleaseDefinitionApprovals(approvals []interface{}) *release.ReleaseDefinitionApprovals {
+	executionOrder := release.ApprovalExecutionOrderValues.BeforeGates
+	if len(approvals) == 0 || approvals[0] == nil {
+		return &release.ReleaseDefinitionApprovals{
+			Approvals: &[]release.ReleaseDefinitionApprovalStep{{
+				Rank:             converter.Int(1),
+				IsAutomated:      converter.Bool(true),
+				IsNotificationOn: converter.Bool(false),
+			}},
+			ApprovalOptions: &release.ApprovalOptions{
+				ExecutionOrder: &executionOrder,
+			},
+		}
+	}
+
+	approvalMap := approvals[0].(map[string]interface{})
+	approverIDs := approvalMap["approver_ids"].([]interface{})
+	steps := make([]release.ReleaseDefinitionApprovalStep, 0, len(approverIDs))
+	for i, approverID := range approverIDs {
+		steps = append(steps, release.ReleaseDefinitionApprovalStep{
+			Rank:             converter.Int(i + 1),
+			IsAutomated:      converter.Bool(false),
+			IsNotificationOn: converter.Bool(false),
+			Approver:         &webapi.IdentityRef{Id: converter.String(approverID.(string))},
+		})
+	}
+
+	executionOrder = release.ApprovalExecutionOrder(approvalMap["execution_order"].(string))
+	return &release.ReleaseDefinitionApprovals{
+		Approvals: &steps,
+		ApprovalOptions: &release.ApprovalOptions{
+			RequiredApproverCount:                                   converter.Int(approvalMap["required_approver_count"].(int)),
+			ReleaseCreatorCanBeApprover:                             converter.Bool(approvalMap["release_creator_can_be_approver"].(bool)),
+			AutoTriggeredAndPreviousEnvironmentApprovedCanBeSkipped: converter.Bool(approvalMap["skip_if_previously_approved"].(bool)),
+			EnforceIdentityRevalidation:                             converter.Bool(approvalMap["enforce_identity_revalidation"].(bool)),
+			TimeoutInMinutes:                                        converter.Int(approvalMap["timeout_in_minutes"].(int)),
+			ExecutionOrder:                                          &executionOrder,
+		},
+	}
+}
+
+// flattenReleaseDefinitionApprovals returns no approval for automated approvals.
+func flattenReleaseDefinitionApprovals(approvals *release.ReleaseDefinitionApprovals) []interface{} {
+	if approvals == nil || approvals.Approvals == nil {
+		return nil
+	}
+
+	steps := make([]release.ReleaseDefinitionApprovalStep, len(*approvals.Approvals))
+	copy(steps, *approvals.Approvals)
+	sort.SliceStable(steps, func(i, j int) bool {
+		return converter.ToInt(steps[i].Rank, 0) < converter.ToInt(steps[j].Rank, 0)
+	})
+
+	approverIDs := []interface{}{}
+	for _, step := range steps {
+		if !converter.ToBool(step.IsAutomated, false) && step.Approver != nil {
+			approverIDs = append(approverIDs, converter.ToString(step.Approver.Id, ""))
+		}
+	}
+	if len(approverIDs) == 0 {
+		return nil
+	}
+
+	approval := map[string]interface{}{
+		"approver_ids": approverIDs,
+	}
+	if options := approvals.ApprovalOptions; options != nil {
+		approval["required_approver_count"] = converter.ToInt(options.RequiredApproverCount, 0)
+		approval["release_creator_can_be_approver"] = converter.ToBool(options.ReleaseCreatorCanBeApprover, false)
+		approval["skip_if_previously_approved"] = converter.ToBool(options.AutoTriggeredAndPreviousEnvironmentApprovedCanBeSkipped, false)
+		approval["enforce_identity_revalidation"] = converter.ToBool(options.EnforceIdentityRevalidation, false)
+		approval["timeout_in_minutes"] = converter.ToInt(options.TimeoutInMinutes, 0)
+		approval["execution_order"] = converter.ToString((*string)(options.ExecutionOrder), "")
+	}
+	return []interface{}{approval}
+}
+
+func expandReleaseDefinitionGates(gates []interface{}) *release.ReleaseDefinitionGatesStep {
+	if len(gates) == 0 || gates[0] == nil {
+		return &release.ReleaseDefinitionGatesStep{
+			Gates: &[]release.ReleaseDefinitionGate{},
+		}
+	}
+
+	gateMap := gates[0].(map[string]interface{})
+	return &release.ReleaseDefinitionGatesStep{
+		Gates: &[]release.ReleaseDefinitionGate{{
+			Tasks: expandWorkflowTasks(gateMap[rdTask].([]interface{})),
+		}},
+		GatesOptions: &release.ReleaseDefinitionGatesOptions{
+			IsEnabled:              converter.Bool(true),
+			Timeout:                converter.Int(gateMap["timeout_in_minutes"].(int)),
+			SamplingInterval:       converter.Int(gateMap["sampling_interval_in_minutes"].(int)),
+			StabilizationTime:      converter.Int(gateMap["stabilization_time_in_minutes"].(int)),
+			MinimumSuccessDuration: converter.Int(gateMap["minimum_success_duration_in_minutes"].(int)),
+		},
+	}
+}
+
+// flattenReleaseDefinitionGates returns no gate if the gates are disabled or contain no tasks.
+func flattenReleaseDefinitionGates(d *schema.ResourceData, key string, gates *release.ReleaseDefinitionGatesStep) []interface{} {
+	if gates == nil || gates.Gates == nil || gates.GatesOptions == nil || !converter.ToBool(gates.GatesOptions.IsEnabled, false) {
+		return nil
+	}
+
+	tasks := []release.WorkflowTask{}
+	for _, gate := range *gates.Gates {
+		if gate.Tasks != nil {
+			tasks = append(tasks, *gate.Tasks...)
+		}
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	options := gates.GatesOptions
+	return []interface{}{map[string]interface{}{
+		"timeout_in_minutes":                  converter.ToInt(options.Timeout, 0),
+		"sampling_interval_in_minutes":        converter.ToInt(options.SamplingInterval, 0),
+		"stabilization_time_in_minutes":       converter.ToInt(options.StabilizationTime, 0),
+		"minimum_success_duration_in_minutes": converter.ToInt(options.MinimumSuccessDuration, 0),
+		rdTask:                                flattenWorkflowTasks(d, key, &tasks),
+	}}
+}
+
+func expandReleaseDefinitionRetentionPolicy(policies []interface{}) *release.EnvironmentRetentionPolicy {
+	if len(policies) == 0 || policies[0] == nil {
+		return &release.EnvironmentRetentionPolicy{
+			DaysToKeep:     converter.Int(30),
+			ReleasesToKeep: converter.Int(3),
+			RetainBuild:    converter.Bool(true),
+		}
+	}
+
+	policyMap := policies[0].(map[string]interface{})
+	return &release.EnvironmentRetentionPolicy{
+		DaysToKeep:     converter.Int(policyMap["days_to_keep"].(int)),
+		ReleasesToKeep: converter.Int(policyMap["releases_to_keep"].(int)),
+		RetainBuild:    converter.Bool(policyMap["retain_build"].(bool)),
+	}
+}
+
+func flattenReleaseDefinitionRetentionPolicy(policy *release.EnvironmentRetentionPolicy) []interface{} {
+	if policy == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"days_to_keep":     converter.ToInt(policy.DaysToKeep, 0),
+		"releases_to_keep": converter.ToInt(policy.ReleasesToKeep, 0),
+		"retain_build":     converter.ToBool(policy.RetainBuild, false),
+	}}
+}
+
+func expandReleaseDefinitionDeployPhases(phases []interface{}) (*[]interface{}, error) {
+	deployPhases := make([]interface{}, 0, len(phases))
+	for i, phase := range phases {
+		phaseMap := phase.(map[string]interface{})
+		name := phaseMap["name"].(string)
+		phaseType := release.DeployPhaseTypes(phaseMap["type"].(string))
+		parallelExecutionType := release.ParallelExecutionTypesValues.None
+
+		var refName *string
+		if value := phaseMap["ref_name"].(string); value != "" {
+			refName = converter.String(value)
+		}
+
+		switch phaseType {
+		case release.DeployPhaseTypesValues.AgentBasedDeployment:
+			queueID := phaseMap["queue_id"].(int)
+			if queueID == 0 {
+				return nil, fmt.Errorf("Deploy phase %s of type %s requires a queue_id", name, phaseType)
+			}
+
+			deploymentInput := release.AgentDeploymentInput{
+				QueueId:                   converter.Int(queueID),
+				Condition:                 converter.String(phaseMap["condition"].(string)),
+				TimeoutInMinutes:          converter.Int(phaseMap["timeout_in_minutes"].(int)),
+				JobCancelTimeoutInMinutes: converter.Int(phaseMap["job_cancel_timeout_in_minutes"].(int)),
+				SkipArtifactsDownload:     converter.Bool(phaseMap["skip_artifacts_download"].(bool)),
+				EnableAccessToken:         converter.Bool(phaseMap["enable_access_token"].(bool)),
+				Demands:                   expandInterfaceList(phaseMap["demands"].([]interface{})),
+				ArtifactsDownloadInput: &release.ArtifactsDownloadInput{
+					DownloadInputs: &[]release.ArtifactDownloadInputBase{},
+				},
+				ParallelExecution: &release.ExecutionInput{
+					ParallelExecutionType: &parallelExecutionType,
+				},
+			}
+			if agentSpecification := phaseMap["agent_specification"].(string); agentSpecification != "" {
+				deploymentInput.AgentSpecification = &release.AgentSpecification{
+					Identifier: converter.String(agentSpecification),
+				}
+			}
+
+			deployPhases = append(deployPhases, release.AgentBasedDeployPhase{
+				Name:            converter.String(name),
+				PhaseType:       &phaseType,
+				Rank:            converter.Int(i + 1),
+				RefName:         refName,
+				WorkflowTasks:   expandWorkflowTasks(phaseMap[rdTask].([]interface{})),
+				DeploymentInput: &deploymentInput,
+			})
+		case release.DeployPhaseTypesValues.RunOnServer:
+			deployPhases = append(deployPhases, release.RunOnServerDeployPhase{
+				Name:          converter.String(name),
+				PhaseType:     &phaseType,
+				Rank:          converter.Int(i + 1),
+				RefName:       refName,
+				WorkflowTasks: expandWorkflowTasks(phaseMap[rdTask].([]interface{})),
+				DeploymentInput: &release.ServerDeploymentInput{
+					Condition:                 converter.String(phaseMap["condition"].(string)),
+					TimeoutInMinutes:          converter.Int(phaseMap["timeout_in_minutes"].(int)),
+					JobCancelTimeoutInMinutes: converter.Int(phaseMap["job_cancel_timeout_in_minutes"].(int)),
+					ParallelExecution: &release.ExecutionInput{
+						ParallelExecutionType: &parallelExecutionType,
+					},
+				},
+			})
+		default:
+			return nil, fmt.Errorf("Deploy phase %s has the unsupported type %s", name, phaseType)
+		}
+	}
+	return &deployPhases, nil
+}
+
+// flattenReleaseDefinitionDeployPhases returns the deploy phases of the stage at the given key
+// ordered by their rank.
+func flattenReleaseDefinitionDeployPhases(d *schema.ResourceData, key string, phases *[]interface{}) ([]interface{}, error) {
+	if phases == nil {
+		return nil, nil
+	}
+
+	// The input of agentless phases is a subset of the input of agent phases, so that all phases
+	// can be decoded as agent phases.
+	deployPhases := make([]release.AgentBasedDeployPhase, 0, len(*phases))
+	for _, phase := range *phases {
+		var deployPhase release.AgentBasedDeployPhase
+		if err := decodeReleaseDefinitionItem(phase, &deployPhase); err != nil {
+			return nil, err
+		}
+		deployPhases = append(deployPhases, deployPhase)
+	}
+	sort.SliceStable(deployPhases, func(i, j int) bool {
+		return converter.ToInt(deployPhases[i].Rank, 0) < converter.ToInt(deployPhases[j].Rank, 0)
+	})
+
+	results := make([]interface{}, 0, len(deployPhases))
+	for i, deployPhase := range deployPhases {
+		result := map[string]interface{}{
+			"name":     converter.ToString(deployPhase.Name, ""),
+			"ref_name": converter.ToString(deployPhase.RefName, ""),
+			"type":     converter.ToString((*string)(deployPhase.PhaseType), ""),
+			rdTask:     flattenWorkflowTasks(d, fmt.Sprintf("%s.%s.%d", key, rdDeployPhase, i), deployPhase.WorkflowTasks),
+		}
+		if input := deployPhase.DeploymentInput; input != nil {
+			result["queue_id"] = converter.ToInt(input.QueueId, 0)
+			result["condition"] = converter.ToString(input.Condition, "")
+			result["timeout_in_minutes"] = converter.ToInt(input.TimeoutInMinutes, 0)
+			result["job_cancel_timeout_in_minutes"] = converter.ToInt(input.JobCancelTimeoutInMinutes, 0)
+			result["skip_artifacts_download"] = converter.ToBool(input.SkipArtifactsDownload, false)
+			result["enable_access_token"] = converter.ToBool(input.EnableAccessToken, false)
+			result["demands"] = flattenInterfaceList(input.Demands)
+			if input.AgentSpecification != nil {
+				result["agent_specification"] = converter.ToString(input.AgentSpecification.Identifier, "")
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func expandWorkflowTasks(tasks []interface{}) *[]release.WorkflowTask {
+	workflowTasks := make([]release.WorkflowTask, 0, len(tasks))
+	for _, task := range tasks {
+		taskMap, ok := task.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		workflowTask := release.WorkflowTask{
+			TaskId:           converter.UUID(taskMap["task_id"].(string)),
+			Version:          converter.String(taskMap["version"].(string)),
+			DefinitionType:   converter.String(taskMap["definition_type"].(string)),
+			Name:             converter.String(taskMap["display_name"].(string)),
+			Enabled:          converter.Bool(taskMap["enabled"].(bool)),
+			ContinueOnError:  converter.Bool(taskMap["continue_on_error"].(bool)),
+			AlwaysRun:        converter.Bool(taskMap["always_run"].(bool)),
+			Condition:        converter.String(taskMap["condition"].(string)),
+			TimeoutInMinutes: converter.Int(taskMap["timeout_in_minutes"].(int)),
+			Inputs:           expandStringMap(taskMap["inputs"]),
+			Environment:      expandStringMap(taskMap["environment"]),
+		}
+		if refName := taskMap["ref_name"].(string); refName != "" {
+			workflowTask.RefName = converter.String(refName)
+		}
+		workflowTasks = append(workflowTasks, workflowTask)
+	}
+	return &workflowTasks
+}
+
+// flattenWorkflowTasks returns the tasks of the block at the given key. The service returns all inputs
+// of a task including the ones using the default value, only the inputs which are part of the state are kept.
+func flattenWorkflowTasks(d *schema.ResourceData, key string, workflowTasks *[]release.WorkflowTask) []interface{} {
+	if workflowTasks == nil {
+		return nil
+	}
+
+	results := make([]interface{}, 0, len(*workflowTasks))
+	for i, workflowTask := range *workflowTasks {
+		inputs := tfhelper.FilterMapToStateKeys(d, fmt.Sprintf("%s.%s", key, rdTask), i, "inputs", flattenStringMap(workflowTask.Inputs))
+
+		taskID := ""
+		if workflowTask.TaskId != nil {
+			taskID = workflowTask.TaskId.String()
+		}
+		results = append(results, map[string]interface{}{
+			"task_id":            taskID,
+			"version":            converter.ToString(workflowTask.Version, ""),
+			"definition_type":    converter.ToString(workflowTask.DefinitionType, ""),
+			"display_name":       converter.ToString(workflowTask.Name, ""),
+			"ref_name":           converter.ToString(workflowTask.RefName, ""),
+			"enabled":            converter.ToBool(workflowTask.Enabled, false),
+			"continue_on_error":  converter.ToBool(workflowTask.ContinueOnError, false),
+			"always_run":         converter.ToBool(workflowTask.AlwaysRun, false),
+			"condition":          converter.ToString(workflowTask.Condition, ""),
+			"timeout_in_minutes": converter.ToInt(workflowTask.TimeoutInMinutes, 0),
+			"inputs":             inputs,
+			"environment":        flattenStringMap(workflowTask.Environment),
+		})
+	}
+	return results
+}
+
+// decodeReleaseDefinitionItem decodes an untyped item of a release definition, like a trigger or a
+// deploy phase, which is either returned by the service as raw JSON structure or has been expanded
+// by this provider.
+func decodeReleaseDefinitionItem(item interface{}, target interface{}) error {
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(itemJSON, target)
+}
+
+func expandVariableGroups(variableGroups *schema.Set) *[]int {
+	results := make([]int, 0, variableGroups.Len())
+	for _, variableGroup := range variableGroups.List() {
+		results = append(results, variableGroup.(int))
+	}
+	return &results
+}
+
+func expandStringSet(values *schema.Set) *[]string {
+	results := tfhelper.ExpandStringSet(values)
+	return &results
+}
+
+func expandStringList(values []interface{}) *[]string {
+	results := tfhelper.ExpandStringList(values)
+	return &results
+}
+
+func expandInterfaceList(values []interface{}) *[]interface{} {
+	results := make([]interface{}, 0, len(values))
+	results = append(results, values...)
+	return &results
+}
+
+func expandStringMap(v interface{}) *map[string]string {
+	results := map[string]string{}
+	if m, ok := v.(map[string]interface{}); ok {
+		for key, value := range m {
+			results[key] = fmt.Sprint(value)
+		}
+	}
+	return &results
+}
+
+func flattenStringMap(values *map[string]string) map[string]interface{} {
+	results := map[string]interface{}{}
+	if values != nil {
+		for key, value := range *values {
+			results[key] = value
+		}
+	}
+	return results
+}
+
+func flattenStringList(values *[]string) []interface{} {
+	results := []interface{}{}
+	if values != nil {
+		for _, value := range *values {
+			results = append(results, value)
+		}
+	}
+	return results
+}
+
+func flattenIntList(values *[]int) []interface{} {
+	results := []interface{}{}
+	if values != nil {
+		for _, value := range *values {
+			results = append(results, value)
+		}
+	}
+	return results
+}
+
+func flattenInterfaceList(values *[]interface{}) []interface{} {
+	results := []interface{}{}
+	if values != nil {
+		for _, value := range *values {
+			results = append(results, fmt.Sprint(value))
+		}
+	}
+	return results
+}
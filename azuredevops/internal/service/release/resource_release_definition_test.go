@@ -0,0 +1,358 @@
+// +build all resource_release_definition
+// +build !exclude_resource_release_definition
+
+package release
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/release"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testProjectID = uuid.New().String()
+var testOwnerID = uuid.New().String()
+var testTaskID = uuid.New().String()
+
+func getTestStage(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":     name,
+		"owner_id": testOwnerID,
+		"condition": []interface{}{
+			map[string]interface{}{
+				"type":  "event",
+				"name":  "ReleaseStarted",
+				"value": "",
+			},
+		},
+		"pre_deploy_approval": []interface{}{
+			map[string]interface{}{
+				"approver_ids":            []interface{}{testOwnerID},
+				"required_approver_count": 1,
+				"execution_order":         "afterSuccessfulGates",
+			},
+		},
+		"post_deploy_gate": []interface{}{
+			map[string]interface{}{
+				"timeout_in_minutes": 60,
+				"task": []interface{}{
+					map[string]interface{}{
+						"task_id":      testTaskID,
+						"version":      "1.*",
+						"display_name": "Query work items",
+						"inputs": map[string]interface{}{
+							"queryId": "00000000-0000-0000-0000-000000000000",
+						},
+					},
+				},
+			},
+		},
+		"deploy_phase": []interface{}{
+			map[string]interface{}{
+				"name":     "Agent job",
+				"type":     "agentBasedDeployment",
+				"queue_id": 5,
+				"demands":  []interface{}{"Agent.OS -equals Linux"},
+				"task": []interface{}{
+					map[string]interface{}{
+						"task_id":      testTaskID,
+						"version":      "2.*",
+						"display_name": "Run script",
+						"inputs": map[string]interface{}{
+							"script": "echo deploy",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getTestReleaseDefinitionResourceData(t *testing.T) *schema.ResourceData {
+	resourceData := schema.TestResourceDataRaw(t, ResourceReleaseDefinition().Schema, nil)
+	resourceData.Set("project_id", testProjectID)
+	resourceData.Set("name", "Sample Release")
+	resourceData.Set("artifact", []interface{}{
+		map[string]interface{}{
+			"alias": "_build",
+			"type":  "Build",
+			"definition_reference": map[string]interface{}{
+				"project":    testProjectID,
+				"definition": "1",
+			},
+		},
+	})
+	resourceData.Set("artifact_trigger", []interface{}{
+		map[string]interface{}{
+			"artifact_alias": "_build",
+			"condition": []interface{}{
+				map[string]interface{}{
+					"source_branch": "master",
+				},
+			},
+		},
+	})
+	resourceData.Set("schedule_trigger", []interface{}{
+		map[string]interface{}{
+			"days_to_release": []interface{}{"monday", "friday"},
+			"start_hours":     3,
+		},
+	})
+	resourceData.Set("stage", []interface{}{getTestStage("Dev"), getTestStage("Prod")})
+	return resourceData
+}
+
+// verifies that the configuration of a release definition survives an expand/flatten roundtrip
+func TestReleaseDefinition_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := getTestReleaseDefinitionResourceData(t)
+
+	releaseDefinition, projectID, err := expandReleaseDefinition(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, testProjectID, projectID)
+	require.Nil(t, releaseDefinition.Id)
+	require.True(t, *(*releaseDefinition.Artifacts)[0].IsPrimary)
+	require.Equal(t, release.ScheduleDays("monday, friday"), *(*releaseDefinition.Triggers)[1].(release.ScheduledReleaseTrigger).Schedule.DaysToRelease)
+
+	dev := (*releaseDefinition.Environments)[0]
+	require.Equal(t, 1, *dev.Rank)
+	require.Equal(t, testOwnerID, *dev.Owner.Id)
+	require.False(t, *(*dev.PreDeployApprovals.Approvals)[0].IsAutomated)
+	require.True(t, *(*dev.PostDeployApprovals.Approvals)[0].IsAutomated)
+	require.Len(t, *dev.PreDeploymentGates.Gates, 0)
+	require.True(t, *dev.PostDeploymentGates.GatesOptions.IsEnabled)
+
+	releaseDefinition.Id = converter.Int(10)
+	releaseDefinition.Revision = converter.Int(2)
+	err = flattenReleaseDefinition(resourceData, releaseDefinition, projectID)
+	require.Nil(t, err)
+
+	roundtripDefinition, _, err := expandReleaseDefinition(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, 10, *roundtripDefinition.Id)
+	require.Equal(t, 2, *roundtripDefinition.Revision)
+
+	roundtripDefinition.Id = nil
+	roundtripDefinition.Revision = nil
+	releaseDefinition.Id = nil
+	releaseDefinition.Revision = nil
+	require.Equal(t, releaseDefinition, roundtripDefinition)
+}
+
+// verifies that the inputs of the tasks which are returned by the service but not configured are not added to the state
+func TestReleaseDefinition_Flatten_KeepsConfiguredInputs(t *testing.T) {
+	resourceData := getTestReleaseDefinitionResourceData(t)
+
+	releaseDefinition, projectID, err := expandReleaseDefinition(resourceData)
+	require.Nil(t, err)
+	releaseDefinition.Id = converter.Int(10)
+
+	gateTask := &(*(*(*releaseDefinition.Environments)[0].PostDeploymentGates.Gates)[0].Tasks)[0]
+	(*gateTask.Inputs)["queryType"] = "flat"
+
+	err = flattenReleaseDefinition(resourceData, releaseDefinition, projectID)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"queryId": "00000000-0000-0000-0000-000000000000",
+	}, resourceData.Get("stage.0.post_deploy_gate.0.task.0.inputs"))
+
+	// all inputs are kept if the tasks are not part of the state, e.g. during an import
+	importedResourceData := schema.TestResourceDataRaw(t, ResourceReleaseDefinition().Schema, nil)
+	err = flattenReleaseDefinition(importedResourceData, releaseDefinition, projectID)
+	require.Nil(t, err)
+	require.Equal(t, "flat", importedResourceData.Get("stage.0.post_deploy_gate.0.task.0.inputs.queryType"))
+}
+
+// verifies that the stages are flattened in the order of their rank
+func TestReleaseDefinition_Flatten_OrdersStagesByRank(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceReleaseDefinition().Schema, nil)
+	releaseDefinition := &release.ReleaseDefinition{
+		Id: converter.Int(10),
+		Environments: &[]release.ReleaseDefinitionEnvironment{
+			{Id: converter.Int(3), Name: converter.String("Prod"), Rank: converter.Int(3)},
+			{Id: converter.Int(1), Name: converter.String("Dev"), Rank: converter.Int(1)},
+			{Id: converter.Int(2), Name: converter.String("Test"), Rank: converter.Int(2)},
+		},
+	}
+
+	err := flattenReleaseDefinition(resourceData, releaseDefinition, testProjectID)
+	require.Nil(t, err)
+	require.Equal(t, "Dev", resourceData.Get("stage.0.name"))
+	require.Equal(t, "Test", resourceData.Get("stage.1.name"))
+	require.Equal(t, "Prod", resourceData.Get("stage.2.name"))
+	require.Equal(t, 3, resourceData.Get("stage.2.id"))
+}
+
+// verifies that the IDs of the stages are kept by their names if the stages are reordered
+func TestReleaseDefinition_Expand_KeepsStageIDsByName(t *testing.T) {
+	resourceData := getTestReleaseDefinitionResourceData(t)
+	resourceData.SetId("10")
+	resourceData.Set("revision", 4)
+	stages := resourceData.Get("stage").([]interface{})
+	stages[0].(map[string]interface{})["id"] = 1
+	stages[1].(map[string]interface{})["id"] = 2
+	resourceData.Set("stage", stages)
+
+	resourceData = ResourceReleaseDefinition().Data(resourceData.State())
+	resourceData.Set("stage", []interface{}{getTestStage("Prod"), getTestStage("QA"), getTestStage("Dev")})
+
+	releaseDefinition, _, err := expandReleaseDefinition(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, 10, *releaseDefinition.Id)
+	require.Equal(t, 4, *releaseDefinition.Revision)
+
+	environments := *releaseDefinition.Environments
+	require.Equal(t, "Prod", *environments[0].Name)
+	require.Equal(t, 2, *environments[0].Id)
+	require.Equal(t, 1, *environments[0].Rank)
+	require.Equal(t, "QA", *environments[1].Name)
+	require.Nil(t, environments[1].Id)
+	require.Equal(t, "Dev", *environments[2].Name)
+	require.Equal(t, 1, *environments[2].Id)
+	require.Equal(t, 3, *environments[2].Rank)
+}
+
+// verifies that an agent deploy phase requires a queue
+func TestReleaseDefinition_Expand_AgentPhaseRequiresQueue(t *testing.T) {
+	resourceData := getTestReleaseDefinitionResourceData(t)
+	stage := getTestStage("Dev")
+	stage["deploy_phase"].([]interface{})[0].(map[string]interface{})["queue_id"] = 0
+	resourceData.Set("stage", []interface{}{stage})
+
+	_, _, err := expandReleaseDefinition(resourceData)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "requires a queue_id")
+}
+
+// verifies that the days of a schedule are read from the flags enum, including all days
+func TestReleaseDefinition_FlattenScheduleDays(t *testing.T) {
+	days := release.ScheduleDays("tuesday, saturday")
+	require.Equal(t, []interface{}{"tuesday", "saturday"}, flattenScheduleDays(&days))
+
+	days = release.ScheduleDaysValues.All
+	require.Len(t, flattenScheduleDays(&days), 7)
+}
+
+// verifies that if an error is produced on create, it is not swallowed
+func TestReleaseDefinition_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	resourceData := getTestReleaseDefinitionResourceData(t)
+
+	releaseClient.
+		EXPECT().
+		CreateReleaseDefinition(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreateReleaseDefinition() Failed")).
+		Times(1)
+
+	err := resourceReleaseDefinitionCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "CreateReleaseDefinition() Failed")
+}
+
+// verifies that if an error is produced on read, it is not swallowed
+func TestReleaseDefinition_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	resourceData := getTestReleaseDefinitionResourceData(t)
+	resourceData.SetId("10")
+
+	releaseClient.
+		EXPECT().
+		GetReleaseDefinition(clients.Ctx, release.GetReleaseDefinitionArgs{
+			Project:      &testProjectID,
+			DefinitionId: converter.Int(10),
+		}).
+		Return(nil, errors.New("GetReleaseDefinition() Failed")).
+		Times(1)
+
+	err := resourceReleaseDefinitionRead(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "GetReleaseDefinition() Failed")
+}
+
+// verifies that a deleted release definition is removed from the state
+func TestReleaseDefinition_Read_RemovesDeletedDefinition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	resourceData := getTestReleaseDefinitionResourceData(t)
+	resourceData.SetId("10")
+
+	releaseClient.
+		EXPECT().
+		GetReleaseDefinition(clients.Ctx, gomock.Any()).
+		Return(&release.ReleaseDefinition{Id: converter.Int(10), IsDeleted: converter.Bool(true)}, nil).
+		Times(1)
+
+	err := resourceReleaseDefinitionRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that if an error is produced on update, it is not swallowed
+func TestReleaseDefinition_Update_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	resourceData := getTestReleaseDefinitionResourceData(t)
+	resourceData.SetId("10")
+	resourceData.Set("revision", 3)
+
+	releaseClient.
+		EXPECT().
+		UpdateReleaseDefinition(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("UpdateReleaseDefinition() Failed")).
+		Times(1)
+
+	err := resourceReleaseDefinitionUpdate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "UpdateReleaseDefinition() Failed")
+	require.Contains(t, err.Error(), "revision 3")
+}
+
+// verifies that if an error is produced on delete, it is not swallowed
+func TestReleaseDefinition_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	resourceData := getTestReleaseDefinitionResourceData(t)
+	resourceData.SetId("10")
+
+	releaseClient.
+		EXPECT().
+		DeleteReleaseDefinition(clients.Ctx, release.DeleteReleaseDefinitionArgs{
+			Project:      &testProjectID,
+			DefinitionId: converter.Int(10),
+		}).
+		Return(errors.New("DeleteReleaseDefinition() Failed")).
+		Times(1)
+
+	err := resourceReleaseDefinitionDelete(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "DeleteReleaseDefinition() Failed")
+}
@@ -0,0 +1,150 @@
+package taskagent
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// DataDeploymentTargets schema and implementation for deployment targets data source
+func DataDeploymentTargets() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDeploymentTargetsRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"deployment_group_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"targets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"agent_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"online": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"os_description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDeploymentTargetsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	deploymentGroupID := d.Get("deployment_group_id").(int)
+	tags := tfhelper.ExpandStringSet(d.Get("tags").(*schema.Set))
+	sort.Strings(tags)
+
+	targets, err := getDeploymentTargets(clients, projectID, deploymentGroupID, tags)
+	if err != nil {
+		return fmt.Errorf("Error reading targets of deployment group %d in project %s: %+v", deploymentGroupID, projectID, err)
+	}
+
+	h := sha1.New()
+	if _, err := h.Write([]byte(strings.Join(append([]string{projectID, strconv.Itoa(deploymentGroupID)}, tags...), "#"))); err != nil {
+		return fmt.Errorf("Unable to compute hash for deployment targets filter: %v", err)
+	}
+	d.SetId("targets#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+
+	err = d.Set("targets", flattenDeploymentTargets(targets))
+	if err != nil {
+		return fmt.Errorf("Error setting targets field in state. Error: %v", err)
+	}
+	return nil
+}
+
+func flattenDeploymentTargets(targets *[]taskagent.DeploymentMachine) []interface{} {
+	result := []interface{}{}
+	for _, target := range *targets {
+		tags := []interface{}{}
+		if target.Tags != nil {
+			for _, tag := range *target.Tags {
+				tags = append(tags, tag)
+			}
+		}
+
+		flattened := map[string]interface{}{
+			"id":   converter.ToInt(target.Id, 0),
+			"tags": schema.NewSet(schema.HashString, tags),
+		}
+		if agent := target.Agent; agent != nil {
+			status := ""
+			if agent.Status != nil {
+				status = string(*agent.Status)
+			}
+			flattened["agent_id"] = converter.ToInt(agent.Id, 0)
+			flattened["name"] = converter.ToString(agent.Name, "")
+			flattened["status"] = status
+			flattened["online"] = strings.EqualFold(status, string(taskagent.TaskAgentStatusValues.Online))
+			flattened["enabled"] = converter.ToBool(agent.Enabled, false)
+			flattened["os_description"] = converter.ToString(agent.OsDescription, "")
+			flattened["version"] = converter.ToString(agent.Version, "")
+		}
+		result = append(result, flattened)
+	}
+	return result
+}
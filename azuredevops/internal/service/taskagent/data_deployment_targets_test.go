@@ -0,0 +1,91 @@
+// +build all data_sources data_deployment_targets
+// +build !exclude_data_sources !exclude_data_deployment_targets
+
+package taskagent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testDeploymentTargetsProjectID = "9083e944-8e9e-405e-960a-c80180aa71e6"
+
+// verifies that the targets with the tags are listed with their online status
+func TestDataSourceDeploymentTargets_Read_FiltersByTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetDeploymentTargets(clients.Ctx, taskagent.GetDeploymentTargetsArgs{
+			Project:           converter.String(testDeploymentTargetsProjectID),
+			DeploymentGroupId: converter.Int(7),
+			Tags:              &[]string{"canary", "web"},
+		}).
+		Return(&taskagent.GetDeploymentTargetsResponseValue{
+			Value: []taskagent.DeploymentMachine{{
+				Id:   converter.Int(2),
+				Tags: &[]string{"web", "canary"},
+				Agent: &taskagent.TaskAgent{
+					Id:            converter.Int(12),
+					Name:          converter.String("web-02"),
+					Status:        &taskagent.TaskAgentStatusValues.Online,
+					Enabled:       converter.Bool(true),
+					OsDescription: converter.String("Linux"),
+					Version:       converter.String("2.170.1"),
+				},
+			}},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataDeploymentTargets().Schema, map[string]interface{}{
+		"project_id":          testDeploymentTargetsProjectID,
+		"deployment_group_id": 7,
+		"tags":                []interface{}{"web", "canary"},
+	})
+	err := dataSourceDeploymentTargetsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.NotEmpty(t, resourceData.Id())
+	require.Equal(t, 1, resourceData.Get("targets.#"))
+	require.Equal(t, 2, resourceData.Get("targets.0.id"))
+	require.Equal(t, 12, resourceData.Get("targets.0.agent_id"))
+	require.Equal(t, "web-02", resourceData.Get("targets.0.name"))
+	require.Equal(t, "online", resourceData.Get("targets.0.status"))
+	require.Equal(t, true, resourceData.Get("targets.0.online"))
+	require.Equal(t, true, resourceData.Get("targets.0.enabled"))
+	require.ElementsMatch(t, []interface{}{"web", "canary"}, resourceData.Get("targets.0.tags").(*schema.Set).List())
+}
+
+func TestDataSourceDeploymentTargets_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetDeploymentTargets(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetDeploymentTargets() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataDeploymentTargets().Schema, map[string]interface{}{
+		"project_id":          testDeploymentTargetsProjectID,
+		"deployment_group_id": 7,
+	})
+	err := dataSourceDeploymentTargetsRead(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "GetDeploymentTargets() Failed")
+}
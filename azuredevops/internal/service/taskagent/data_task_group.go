@@ -0,0 +1,80 @@
+package taskagent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// DataTaskGroup schema and implementation for task group data source
+func DataTaskGroup() *schema.Resource {
+	// The data source exposes all attributes of the latest major version of the task group,
+	// the arguments identifying the task group are overridden below.
+	dataSchema := tfhelper.ComputedSchema(ResourceTaskGroup().Schema)
+	dataSchema["project_id"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validation.NoZeroValues,
+		DiffSuppressFunc: suppress.CaseDifference,
+	}
+	dataSchema["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceTaskGroupRead,
+		Schema: dataSchema,
+	}
+}
+
+func dataSourceTaskGroupRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	name := d.Get("name").(string)
+
+	taskGroup, err := getTaskGroupByName(clients, projectID, name)
+	if err != nil {
+		return err
+	}
+
+	return flattenTaskGroup(d, taskGroup, projectID)
+}
+
+// getTaskGroupByName returns the latest major version of the task group with the name, which is compared case insensitive
+func getTaskGroupByName(clients *client.AggregatedClient, projectID string, name string) (*taskagent.TaskGroup, error) {
+	taskGroups, err := clients.TaskAgentClient.GetTaskGroups(clients.Ctx, taskagent.GetTaskGroupsArgs{
+		Project: converter.String(projectID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading task groups of project %s: %+v", projectID, err)
+	}
+
+	// all major versions of a task group share the ID of the task group
+	versionsByID := map[string][]taskagent.TaskGroup{}
+	if taskGroups != nil {
+		for _, taskGroup := range *taskGroups {
+			if taskGroup.Id != nil && strings.EqualFold(converter.ToString(taskGroup.Name, ""), name) {
+				versionsByID[taskGroup.Id.String()] = append(versionsByID[taskGroup.Id.String()], taskGroup)
+			}
+		}
+	}
+
+	if len(versionsByID) > 1 {
+		return nil, fmt.Errorf("Found multiple task groups with name %s in project %s", name, projectID)
+	}
+	for _, versions := range versionsByID {
+		if taskGroup := selectLatestTaskGroup(&versions); taskGroup != nil {
+			return taskGroup, nil
+		}
+	}
+	return nil, fmt.Errorf("Task group with name %s does not exist in project %s", name, projectID)
+}
@@ -0,0 +1,90 @@
+// +build all data_sources data_task_group
+// +build !exclude_data_sources !exclude_data_task_group
+
+package taskagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestTaskGroupDataSourceClients(ctrl *gomock.Controller, taskGroups []taskagent.TaskGroup) *client.AggregatedClient {
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetTaskGroups(clients.Ctx, taskagent.GetTaskGroupsArgs{
+			Project: converter.String(testTaskGroupProjectID),
+		}).
+		Return(&taskGroups, nil).
+		Times(1)
+	return clients
+}
+
+// verifies that the latest major version of the task group with the name is found
+func TestDataSourceTaskGroup_Read_FindsLatestVersionByName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	otherTaskGroup := getTestTaskGroup(4)
+	otherID := uuid.New()
+	otherTaskGroup.Id = &otherID
+	otherTaskGroup.Name = converter.String("Build Web App")
+	clients := getTestTaskGroupDataSourceClients(ctrl, []taskagent.TaskGroup{
+		getTestTaskGroup(1), getTestTaskGroup(2), otherTaskGroup,
+	})
+
+	resourceData := schema.TestResourceDataRaw(t, DataTaskGroup().Schema, map[string]interface{}{
+		"project_id": testTaskGroupProjectID,
+		"name":       "deploy web app",
+	})
+	err := dataSourceTaskGroupRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testTaskGroupID.String(), resourceData.Id())
+	require.Equal(t, 2, resourceData.Get(tgMajorVersion))
+	require.Equal(t, "Deploy Web App", resourceData.Get("name"))
+	require.Equal(t, "App name", resourceData.Get("input.0.label"))
+}
+
+func TestDataSourceTaskGroup_Read_TaskGroupNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients := getTestTaskGroupDataSourceClients(ctrl, []taskagent.TaskGroup{getTestTaskGroup(1)})
+
+	resourceData := schema.TestResourceDataRaw(t, DataTaskGroup().Schema, map[string]interface{}{
+		"project_id": testTaskGroupProjectID,
+		"name":       "Nonexistent",
+	})
+	err := dataSourceTaskGroupRead(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "does not exist")
+}
+
+func TestDataSourceTaskGroup_Read_MultipleTaskGroupsFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	otherTaskGroup := getTestTaskGroup(1)
+	otherID := uuid.New()
+	otherTaskGroup.Id = &otherID
+	clients := getTestTaskGroupDataSourceClients(ctrl, []taskagent.TaskGroup{getTestTaskGroup(1), otherTaskGroup})
+
+	resourceData := schema.TestResourceDataRaw(t, DataTaskGroup().Schema, map[string]interface{}{
+		"project_id": testTaskGroupProjectID,
+		"name":       "Deploy Web App",
+	})
+	err := dataSourceTaskGroupRead(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Found multiple task groups")
+}
@@ -0,0 +1,98 @@
+package taskagent
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+)
+
+// The SDK contains the models of the pipeline environments, but not the operations of the
+// distributed task environments REST endpoints.
+var (
+	environmentsLocationID        = uuid.MustParse("8572b1fc-2482-47fa-8f74-7e3ed53ee54b")
+	kubernetesResourcesLocationID = uuid.MustParse("73fba52f-15ab-42b3-a538-ce67a9223a04")
+)
+
+const environmentsAPIVersion = "5.1-preview.1"
+
+// using: POST https://dev.azure.com/{organization}/{project}/_apis/distributedtask/environments?api-version=5.1-preview.1
+func addEnvironment(clients *client.AggregatedClient, projectID string, parameters *taskagent.EnvironmentCreateParameter) (*taskagent.EnvironmentInstance, error) {
+	var environment taskagent.EnvironmentInstance
+	err := sendEnvironmentRequest(clients, http.MethodPost, environmentsLocationID, map[string]string{
+		"project": projectID,
+	}, parameters, &environment)
+	return &environment, err
+}
+
+// using: GET https://dev.azure.com/{organization}/{project}/_apis/distributedtask/environments/{environmentId}?api-version=5.1-preview.1
+func getEnvironment(clients *client.AggregatedClient, projectID string, environmentID int) (*taskagent.EnvironmentInstance, error) {
+	var environment taskagent.EnvironmentInstance
+	err := sendEnvironmentRequest(clients, http.MethodGet, environmentsLocationID, map[string]string{
+		"project":       projectID,
+		"environmentId": strconv.Itoa(environmentID),
+	}, nil, &environment)
+	return &environment, err
+}
+
+// using: PATCH https://dev.azure.com/{organization}/{project}/_apis/distributedtask/environments/{environmentId}?api-version=5.1-preview.1
+func updateEnvironment(clients *client.AggregatedClient, projectID string, environmentID int, parameters *taskagent.EnvironmentUpdateParameter) (*taskagent.EnvironmentInstance, error) {
+	var environment taskagent.EnvironmentInstance
+	err := sendEnvironmentRequest(clients, http.MethodPatch, environmentsLocationID, map[string]string{
+		"project":       projectID,
+		"environmentId": strconv.Itoa(environmentID),
+	}, parameters, &environment)
+	return &environment, err
+}
+
+// using: DELETE https://dev.azure.com/{organization}/{project}/_apis/distributedtask/environments/{environmentId}?api-version=5.1-preview.1
+func deleteEnvironment(clients *client.AggregatedClient, projectID string, environmentID int) error {
+	return sendEnvironmentRequest(clients, http.MethodDelete, environmentsLocationID, map[string]string{
+		"project":       projectID,
+		"environmentId": strconv.Itoa(environmentID),
+	}, nil, nil)
+}
+
+// using: POST https://dev.azure.com/{organization}/{project}/_apis/distributedtask/environments/{environmentId}/providers/kubernetes?api-version=5.1-preview.1
+func addKubernetesResource(clients *client.AggregatedClient, projectID string, environmentID int, parameters *taskagent.KubernetesResourceCreateParameters) (*taskagent.KubernetesResource, error) {
+	var resource taskagent.KubernetesResource
+	err := sendEnvironmentRequest(clients, http.MethodPost, kubernetesResourcesLocationID, map[string]string{
+		"project":       projectID,
+		"environmentId": strconv.Itoa(environmentID),
+	}, parameters, &resource)
+	return &resource, err
+}
+
+// using: GET https://dev.azure.com/{organization}/{project}/_apis/distributedtask/environments/{environmentId}/providers/kubernetes/{resourceId}?api-version=5.1-preview.1
+func getKubernetesResource(clients *client.AggregatedClient, projectID string, environmentID int, resourceID int) (*taskagent.KubernetesResource, error) {
+	var resource taskagent.KubernetesResource
+	err := sendEnvironmentRequest(clients, http.MethodGet, kubernetesResourcesLocationID, map[string]string{
+		"project":       projectID,
+		"environmentId": strconv.Itoa(environmentID),
+		"resourceId":    strconv.Itoa(resourceID),
+	}, nil, &resource)
+	return &resource, err
+}
+
+// using: DELETE https://dev.azure.com/{organization}/{project}/_apis/distributedtask/environments/{environmentId}/providers/kubernetes/{resourceId}?api-version=5.1-preview.1
+func deleteKubernetesResource(clients *client.AggregatedClient, projectID string, environmentID int, resourceID int) error {
+	return sendEnvironmentRequest(clients, http.MethodDelete, kubernetesResourcesLocationID, map[string]string{
+		"project":       projectID,
+		"environmentId": strconv.Itoa(environmentID),
+		"resourceId":    strconv.Itoa(resourceID),
+	}, nil, nil)
+}
+
+// sendEnvironmentRequest sends the body, if any, as JSON and unmarshals the response into the result, if any
+func sendEnvironmentRequest(clients *client.AggregatedClient, method string, locationID uuid.UUID, routeValues map[string]string, body interface{}, result interface{}) error {
+	return utils.SendRestRequest(clients.Ctx, clients.TaskAgentClient, &utils.RestRequest{
+		Method:      method,
+		LocationID:  locationID,
+		APIVersion:  environmentsAPIVersion,
+		RouteValues: routeValues,
+		Body:        body,
+	}, result)
+}
@@ -0,0 +1,328 @@
+package taskagent
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceDeploymentGroup schema and implementation for deployment group resource
+func ResourceDeploymentGroup() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceDeploymentGroupCreate,
+		Read:     resourceDeploymentGroupRead,
+		Update:   resourceDeploymentGroupUpdate,
+		Delete:   resourceDeploymentGroupDelete,
+		Importer: tfhelper.ImportProjectQualifiedResourceInteger(),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"pool_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"target": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"tags": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+						},
+					},
+				},
+			},
+			"machine_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDeploymentGroupCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	parameter := &taskagent.DeploymentGroupCreateParameter{
+		Name:        converter.String(d.Get("name").(string)),
+		Description: converter.String(d.Get("description").(string)),
+	}
+	if poolID, ok := d.GetOk("pool_id"); ok {
+		if err := validateDeploymentPool(clients, poolID.(int)); err != nil {
+			return err
+		}
+		parameter.PoolId = converter.Int(poolID.(int))
+	}
+
+	createdDeploymentGroup, err := clients.TaskAgentClient.AddDeploymentGroup(clients.Ctx, taskagent.AddDeploymentGroupArgs{
+		Project:         converter.String(projectID),
+		DeploymentGroup: parameter,
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating deployment group in project %s: %+v", projectID, err)
+	}
+
+	d.SetId(strconv.Itoa(*createdDeploymentGroup.Id))
+
+	if d.Get("target").(*schema.Set).Len() > 0 {
+		if err := updateDeploymentGroupTargetTags(clients, d, projectID, *createdDeploymentGroup.Id); err != nil {
+			return fmt.Errorf("Error updating tags of targets of deployment group %d in project %s: %+v", *createdDeploymentGroup.Id, projectID, err)
+		}
+	}
+	return resourceDeploymentGroupRead(d, m)
+}
+
+func resourceDeploymentGroupRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, deploymentGroupID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	deploymentGroup, err := clients.TaskAgentClient.GetDeploymentGroup(clients.Ctx, taskagent.GetDeploymentGroupArgs{
+		Project:           converter.String(projectID),
+		DeploymentGroupId: converter.Int(deploymentGroupID),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading deployment group %d in project %s: %+v", deploymentGroupID, projectID, err)
+	}
+
+	targets, err := getDeploymentTargets(clients, projectID, deploymentGroupID, nil)
+	if err != nil {
+		return fmt.Errorf("Error reading targets of deployment group %d in project %s: %+v", deploymentGroupID, projectID, err)
+	}
+
+	flattenDeploymentGroup(d, deploymentGroup, projectID)
+	return d.Set("target", flattenDeploymentGroupTargetTags(d.Get("target").(*schema.Set), targets))
+}
+
+func resourceDeploymentGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, deploymentGroupID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChanges("name", "description") {
+		_, err = clients.TaskAgentClient.UpdateDeploymentGroup(clients.Ctx, taskagent.UpdateDeploymentGroupArgs{
+			Project:           converter.String(projectID),
+			DeploymentGroupId: converter.Int(deploymentGroupID),
+			DeploymentGroup: &taskagent.DeploymentGroupUpdateParameter{
+				Name:        converter.String(d.Get("name").(string)),
+				Description: converter.String(d.Get("description").(string)),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Error updating deployment group %d in project %s: %+v", deploymentGroupID, projectID, err)
+		}
+	}
+
+	if d.HasChange("target") {
+		if err := updateDeploymentGroupTargetTags(clients, d, projectID, deploymentGroupID); err != nil {
+			return fmt.Errorf("Error updating tags of targets of deployment group %d in project %s: %+v", deploymentGroupID, projectID, err)
+		}
+	}
+
+	return resourceDeploymentGroupRead(d, m)
+}
+
+func resourceDeploymentGroupDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, deploymentGroupID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	err = clients.TaskAgentClient.DeleteDeploymentGroup(clients.Ctx, taskagent.DeleteDeploymentGroupArgs{
+		Project:           converter.String(projectID),
+		DeploymentGroupId: converter.Int(deploymentGroupID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting deployment group %d in project %s: %+v", deploymentGroupID, projectID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// validateDeploymentPool ensures that a deployment group is not linked to an agent pool of the automation type
+func validateDeploymentPool(clients *client.AggregatedClient, poolID int) error {
+	pool, err := azureAgentPoolRead(clients, poolID)
+	if err != nil {
+		return fmt.Errorf("Error reading agent pool %d: %+v", poolID, err)
+	}
+	if pool.PoolType == nil || !strings.EqualFold(string(*pool.PoolType), string(taskagent.TaskAgentPoolTypeValues.Deployment)) {
+		return fmt.Errorf("Agent pool %d is not a deployment pool, a deployment group requires a pool of type %s", poolID, taskagent.TaskAgentPoolTypeValues.Deployment)
+	}
+	return nil
+}
+
+// updateDeploymentGroupTargetTags sets the tags of the configured targets and removes the tags of targets which are no longer configured
+func updateDeploymentGroupTargetTags(clients *client.AggregatedClient, d *schema.ResourceData, projectID string, deploymentGroupID int) error {
+	targets, err := getDeploymentTargets(clients, projectID, deploymentGroupID, nil)
+	if err != nil {
+		return err
+	}
+
+	oldTargets, newTargets := d.GetChange("target")
+	parameters, unregisteredNames := expandDeploymentTargetTags(oldTargets.(*schema.Set), newTargets.(*schema.Set), targets)
+	for _, name := range unregisteredNames {
+		log.Printf("[WARN] Deployment target %s is not registered in deployment group %d, its tags are not updated", name, deploymentGroupID)
+	}
+
+	if len(parameters) == 0 {
+		return nil
+	}
+	_, err = clients.TaskAgentClient.UpdateDeploymentTargets(clients.Ctx, taskagent.UpdateDeploymentTargetsArgs{
+		Project:           converter.String(projectID),
+		DeploymentGroupId: converter.Int(deploymentGroupID),
+		Machines:          &parameters,
+	})
+	return err
+}
+
+// expandDeploymentTargetTags returns the tags of the registered targets, targets which are no longer configured get no tags.
+// Targets which are not yet registered in the deployment group are returned by name, their tags will be set by the next apply.
+func expandDeploymentTargetTags(oldTargets *schema.Set, newTargets *schema.Set, targets *[]taskagent.DeploymentMachine) ([]taskagent.DeploymentTargetUpdateParameter, []string) {
+	tagsByName := map[string][]string{}
+	for _, raw := range oldTargets.List() {
+		tagsByName[strings.ToLower(raw.(map[string]interface{})["name"].(string))] = []string{}
+	}
+	for _, raw := range newTargets.List() {
+		target := raw.(map[string]interface{})
+		tagsByName[strings.ToLower(target["name"].(string))] = tfhelper.ExpandStringSet(target["tags"].(*schema.Set))
+	}
+
+	parameters := []taskagent.DeploymentTargetUpdateParameter{}
+	for _, target := range *targets {
+		name := strings.ToLower(getDeploymentTargetName(&target))
+		tags, ok := tagsByName[name]
+		if !ok {
+			continue
+		}
+		delete(tagsByName, name)
+		parameters = append(parameters, taskagent.DeploymentTargetUpdateParameter{
+			Id:   target.Id,
+			Tags: &tags,
+		})
+	}
+
+	unregisteredNames := []string{}
+	for name := range tagsByName {
+		unregisteredNames = append(unregisteredNames, name)
+	}
+	return parameters, unregisteredNames
+}
+
+// getDeploymentTargets returns all targets of a deployment group, which have all of the tags
+func getDeploymentTargets(clients *client.AggregatedClient, projectID string, deploymentGroupID int, tags []string) (*[]taskagent.DeploymentMachine, error) {
+	args := taskagent.GetDeploymentTargetsArgs{
+		Project:           converter.String(projectID),
+		DeploymentGroupId: converter.Int(deploymentGroupID),
+	}
+	if len(tags) > 0 {
+		args.Tags = &tags
+	}
+
+	targets := []taskagent.DeploymentMachine{}
+	for {
+		response, err := clients.TaskAgentClient.GetDeploymentTargets(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, response.Value...)
+
+		if response.ContinuationToken == "" {
+			return &targets, nil
+		}
+		args.ContinuationToken = converter.String(response.ContinuationToken)
+	}
+}
+
+func getDeploymentTargetName(target *taskagent.DeploymentMachine) string {
+	if target.Agent == nil {
+		return ""
+	}
+	return converter.ToString(target.Agent.Name, "")
+}
+
+func flattenDeploymentGroup(d *schema.ResourceData, deploymentGroup *taskagent.DeploymentGroup, projectID string) {
+	d.SetId(strconv.Itoa(*deploymentGroup.Id))
+	d.Set("project_id", projectID)
+	d.Set("name", converter.ToString(deploymentGroup.Name, ""))
+	d.Set("description", converter.ToString(deploymentGroup.Description, ""))
+	d.Set("machine_count", converter.ToInt(deploymentGroup.MachineCount, 0))
+	if deploymentGroup.Pool != nil {
+		d.Set("pool_id", converter.ToInt(deploymentGroup.Pool.Id, 0))
+	}
+}
+
+// flattenDeploymentGroupTargetTags returns the tags of the configured targets, targets which are not configured are not managed
+func flattenDeploymentGroupTargetTags(configuredTargets *schema.Set, targets *[]taskagent.DeploymentMachine) []interface{} {
+	configuredNames := map[string]string{}
+	for _, raw := range configuredTargets.List() {
+		name := raw.(map[string]interface{})["name"].(string)
+		configuredNames[strings.ToLower(name)] = name
+	}
+
+	result := []interface{}{}
+	for _, target := range *targets {
+		name, ok := configuredNames[strings.ToLower(getDeploymentTargetName(&target))]
+		if !ok {
+			continue
+		}
+		tags := []interface{}{}
+		if target.Tags != nil {
+			for _, tag := range *target.Tags {
+				tags = append(tags, tag)
+			}
+		}
+		result = append(result, map[string]interface{}{
+			"name": name,
+			"tags": schema.NewSet(schema.HashString, tags),
+		})
+	}
+	return result
+}
@@ -0,0 +1,246 @@
+// +build all resource_deployment_group
+// +build !exclude_resource_deployment_group
+
+package taskagent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testDeploymentGroupProjectID = "9083e944-8e9e-405e-960a-c80180aa71e6"
+
+var testDeploymentGroup = taskagent.DeploymentGroup{
+	Id:           converter.Int(7),
+	Name:         converter.String("Web servers"),
+	Description:  converter.String("Production web servers"),
+	MachineCount: converter.Int(2),
+	Pool: &taskagent.TaskAgentPoolReference{
+		Id: converter.Int(42),
+	},
+}
+
+var testDeploymentTargets = []taskagent.DeploymentMachine{
+	{
+		Id:   converter.Int(1),
+		Tags: &[]string{"web"},
+		Agent: &taskagent.TaskAgent{
+			Id:     converter.Int(11),
+			Name:   converter.String("WEB-01"),
+			Status: &taskagent.TaskAgentStatusValues.Online,
+		},
+	},
+	{
+		Id:   converter.Int(2),
+		Tags: &[]string{"web", "canary"},
+		Agent: &taskagent.TaskAgent{
+			Id:     converter.Int(12),
+			Name:   converter.String("web-02"),
+			Status: &taskagent.TaskAgentStatusValues.Offline,
+		},
+	},
+}
+
+// verifies that the attributes of a deployment group are flattened into the resource data
+func TestDeploymentGroup_Flatten(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceDeploymentGroup().Schema, nil)
+	flattenDeploymentGroup(resourceData, &testDeploymentGroup, testDeploymentGroupProjectID)
+
+	require.Equal(t, "7", resourceData.Id())
+	require.Equal(t, testDeploymentGroupProjectID, resourceData.Get("project_id"))
+	require.Equal(t, "Web servers", resourceData.Get("name"))
+	require.Equal(t, "Production web servers", resourceData.Get("description"))
+	require.Equal(t, 42, resourceData.Get("pool_id"))
+	require.Equal(t, 2, resourceData.Get("machine_count"))
+}
+
+// verifies that only the tags of configured targets are flattened, matching the target names case insensitive
+func TestDeploymentGroup_FlattenTargetTags_OnlyConfiguredTargets(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceDeploymentGroup().Schema, map[string]interface{}{
+		"target": []interface{}{
+			map[string]interface{}{"name": "web-01", "tags": []interface{}{"frontend"}},
+			map[string]interface{}{"name": "web-03", "tags": []interface{}{"frontend"}},
+		},
+	})
+
+	targets := flattenDeploymentGroupTargetTags(resourceData.Get("target").(*schema.Set), &testDeploymentTargets)
+	require.Len(t, targets, 1)
+	target := targets[0].(map[string]interface{})
+	require.Equal(t, "web-01", target["name"])
+	require.ElementsMatch(t, []interface{}{"web"}, target["tags"].(*schema.Set).List())
+}
+
+// verifies that the tags of registered targets are set and the tags of targets removed from the configuration are cleared
+func TestDeploymentGroup_ExpandTargetTags(t *testing.T) {
+	oldResourceData := schema.TestResourceDataRaw(t, ResourceDeploymentGroup().Schema, map[string]interface{}{
+		"target": []interface{}{
+			map[string]interface{}{"name": "web-02", "tags": []interface{}{"canary"}},
+		},
+	})
+	newResourceData := schema.TestResourceDataRaw(t, ResourceDeploymentGroup().Schema, map[string]interface{}{
+		"target": []interface{}{
+			map[string]interface{}{"name": "web-01", "tags": []interface{}{"frontend"}},
+			map[string]interface{}{"name": "web-03", "tags": []interface{}{"frontend"}},
+		},
+	})
+
+	parameters, unregisteredNames := expandDeploymentTargetTags(
+		oldResourceData.Get("target").(*schema.Set),
+		newResourceData.Get("target").(*schema.Set),
+		&testDeploymentTargets)
+	require.ElementsMatch(t, []taskagent.DeploymentTargetUpdateParameter{
+		{Id: converter.Int(1), Tags: &[]string{"frontend"}},
+		{Id: converter.Int(2), Tags: &[]string{}},
+	}, parameters)
+	require.Equal(t, []string{"web-03"}, unregisteredNames)
+}
+
+// verifies that the targets are listed by following the continuation token
+func TestDeploymentGroup_GetDeploymentTargets_FollowsContinuationToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	firstCall := taskAgentClient.
+		EXPECT().
+		GetDeploymentTargets(clients.Ctx, taskagent.GetDeploymentTargetsArgs{
+			Project:           converter.String(testDeploymentGroupProjectID),
+			DeploymentGroupId: converter.Int(7),
+			Tags:              &[]string{"web"},
+		}).
+		Return(&taskagent.GetDeploymentTargetsResponseValue{
+			Value:             testDeploymentTargets[:1],
+			ContinuationToken: "web-02",
+		}, nil).
+		Times(1)
+	taskAgentClient.
+		EXPECT().
+		GetDeploymentTargets(clients.Ctx, taskagent.GetDeploymentTargetsArgs{
+			Project:           converter.String(testDeploymentGroupProjectID),
+			DeploymentGroupId: converter.Int(7),
+			Tags:              &[]string{"web"},
+			ContinuationToken: converter.String("web-02"),
+		}).
+		Return(&taskagent.GetDeploymentTargetsResponseValue{Value: testDeploymentTargets[1:]}, nil).
+		After(firstCall).
+		Times(1)
+
+	targets, err := getDeploymentTargets(clients, testDeploymentGroupProjectID, 7, []string{"web"})
+	require.Nil(t, err)
+	require.Equal(t, testDeploymentTargets, *targets)
+}
+
+func TestDeploymentGroup_Create_RejectsAutomationPool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentPool(clients.Ctx, taskagent.GetAgentPoolArgs{PoolId: converter.Int(42)}).
+		Return(&taskagent.TaskAgentPool{
+			Id:       converter.Int(42),
+			PoolType: &taskagent.TaskAgentPoolTypeValues.Automation,
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceDeploymentGroup().Schema, map[string]interface{}{
+		"project_id": testDeploymentGroupProjectID,
+		"name":       "Web servers",
+		"pool_id":    42,
+	})
+
+	err := resourceDeploymentGroupCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "is not a deployment pool")
+}
+
+func TestDeploymentGroup_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		AddDeploymentGroup(clients.Ctx, taskagent.AddDeploymentGroupArgs{
+			Project: converter.String(testDeploymentGroupProjectID),
+			DeploymentGroup: &taskagent.DeploymentGroupCreateParameter{
+				Name:        converter.String("Web servers"),
+				Description: converter.String(""),
+			},
+		}).
+		Return(nil, errors.New("AddDeploymentGroup() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceDeploymentGroup().Schema, map[string]interface{}{
+		"project_id": testDeploymentGroupProjectID,
+		"name":       "Web servers",
+	})
+
+	err := resourceDeploymentGroupCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "AddDeploymentGroup() Failed")
+}
+
+func TestDeploymentGroup_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetDeploymentGroup(clients.Ctx, taskagent.GetDeploymentGroupArgs{
+			Project:           converter.String(testDeploymentGroupProjectID),
+			DeploymentGroupId: converter.Int(7),
+		}).
+		Return(nil, errors.New("GetDeploymentGroup() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceDeploymentGroup().Schema, nil)
+	flattenDeploymentGroup(resourceData, &testDeploymentGroup, testDeploymentGroupProjectID)
+
+	err := resourceDeploymentGroupRead(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "GetDeploymentGroup() Failed")
+}
+
+func TestDeploymentGroup_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		DeleteDeploymentGroup(clients.Ctx, taskagent.DeleteDeploymentGroupArgs{
+			Project:           converter.String(testDeploymentGroupProjectID),
+			DeploymentGroupId: converter.Int(7),
+		}).
+		Return(errors.New("DeleteDeploymentGroup() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceDeploymentGroup().Schema, nil)
+	flattenDeploymentGroup(resourceData, &testDeploymentGroup, testDeploymentGroupProjectID)
+
+	err := resourceDeploymentGroupDelete(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "DeleteDeploymentGroup() Failed")
+}
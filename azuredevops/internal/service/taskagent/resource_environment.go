@@ -0,0 +1,121 @@
+package taskagent
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceEnvironment schema and implementation for pipeline environment resource
+func ResourceEnvironment() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceEnvironmentCreate,
+		Read:     resourceEnvironmentRead,
+		Update:   resourceEnvironmentUpdate,
+		Delete:   resourceEnvironmentDelete,
+		Importer: tfhelper.ImportProjectQualifiedResourceInteger(),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceEnvironmentCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	createdEnvironment, err := addEnvironment(clients, projectID, &taskagent.EnvironmentCreateParameter{
+		Name:        converter.String(d.Get("name").(string)),
+		Description: converter.String(d.Get("description").(string)),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating environment in project %s: %+v", projectID, err)
+	}
+
+	d.SetId(strconv.Itoa(*createdEnvironment.Id))
+	return resourceEnvironmentRead(d, m)
+}
+
+func resourceEnvironmentRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, environmentID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	environment, err := getEnvironment(clients, projectID, environmentID)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading environment %d in project %s: %+v", environmentID, projectID, err)
+	}
+
+	flattenEnvironment(d, environment, projectID)
+	return nil
+}
+
+func resourceEnvironmentUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, environmentID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = updateEnvironment(clients, projectID, environmentID, &taskagent.EnvironmentUpdateParameter{
+		Name:        converter.String(d.Get("name").(string)),
+		Description: converter.String(d.Get("description").(string)),
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating environment %d in project %s: %+v", environmentID, projectID, err)
+	}
+
+	return resourceEnvironmentRead(d, m)
+}
+
+func resourceEnvironmentDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, environmentID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	err = deleteEnvironment(clients, projectID, environmentID)
+	if err != nil {
+		return fmt.Errorf("Error deleting environment %d in project %s: %+v", environmentID, projectID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func flattenEnvironment(d *schema.ResourceData, environment *taskagent.EnvironmentInstance, projectID string) {
+	d.SetId(strconv.Itoa(*environment.Id))
+	d.Set("project_id", projectID)
+	d.Set("name", converter.ToString(environment.Name, ""))
+	d.Set("description", converter.ToString(environment.Description, ""))
+}
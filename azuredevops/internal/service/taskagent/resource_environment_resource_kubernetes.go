@@ -0,0 +1,179 @@
+package taskagent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceEnvironmentResourceKubernetes schema and implementation for the Kubernetes resource of a pipeline environment
+func ResourceEnvironmentResourceKubernetes() *schema.Resource {
+	// Note: there is no update API, so all fields will require a new resource
+	return &schema.Resource{
+		Create: resourceEnvironmentResourceKubernetesCreate,
+		Read:   resourceEnvironmentResourceKubernetesRead,
+		Delete: resourceEnvironmentResourceKubernetesDelete,
+		Importer: &schema.ResourceImporter{
+			State: importEnvironmentResourceKubernetes,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"environment_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"service_endpoint_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceEnvironmentResourceKubernetesCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	environmentID := d.Get("environment_id").(int)
+
+	parameters, err := expandKubernetesResource(d)
+	if err != nil {
+		return err
+	}
+
+	createdResource, err := addKubernetesResource(clients, projectID, environmentID, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating Kubernetes resource in environment %d of project %s: %+v", environmentID, projectID, err)
+	}
+
+	d.SetId(strconv.Itoa(*createdResource.Id))
+	return resourceEnvironmentResourceKubernetesRead(d, m)
+}
+
+func resourceEnvironmentResourceKubernetesRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, resourceID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+	environmentID := d.Get("environment_id").(int)
+
+	resource, err := getKubernetesResource(clients, projectID, environmentID, resourceID)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Kubernetes resource %d in environment %d of project %s: %+v", resourceID, environmentID, projectID, err)
+	}
+
+	flattenKubernetesResource(d, resource, projectID)
+	return nil
+}
+
+func resourceEnvironmentResourceKubernetesDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, resourceID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+	environmentID := d.Get("environment_id").(int)
+
+	err = deleteKubernetesResource(clients, projectID, environmentID, resourceID)
+	if err != nil {
+		return fmt.Errorf("Error deleting Kubernetes resource %d in environment %d of project %s: %+v", resourceID, environmentID, projectID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// importEnvironmentResourceKubernetes imports a Kubernetes resource by an ID like <project>/<environment ID>/<resource ID>
+func importEnvironmentResourceKubernetes(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected project/environment_id/resource_id", d.Id())
+	}
+
+	environmentID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("environment ID was expected to be integer, but was not: %+v", err)
+	}
+	if _, err := strconv.Atoi(parts[2]); err != nil {
+		return nil, fmt.Errorf("resource ID was expected to be integer, but was not: %+v", err)
+	}
+
+	projectID, err := tfhelper.GetRealProjectId(parts[0], m)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("environment_id", environmentID)
+	d.SetId(parts[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandKubernetesResource(d *schema.ResourceData) (*taskagent.KubernetesResourceCreateParameters, error) {
+	serviceEndpointID, err := uuid.Parse(d.Get("service_endpoint_id").(string))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing service endpoint ID %s: %+v", d.Get("service_endpoint_id").(string), err)
+	}
+
+	return &taskagent.KubernetesResourceCreateParameters{
+		Name:              converter.String(d.Get("name").(string)),
+		Namespace:         converter.String(d.Get("namespace").(string)),
+		ClusterName:       converter.String(d.Get("cluster_name").(string)),
+		ServiceEndpointId: &serviceEndpointID,
+	}, nil
+}
+
+func flattenKubernetesResource(d *schema.ResourceData, resource *taskagent.KubernetesResource, projectID string) {
+	d.SetId(strconv.Itoa(*resource.Id))
+	d.Set("project_id", projectID)
+	if resource.EnvironmentReference != nil && resource.EnvironmentReference.Id != nil {
+		d.Set("environment_id", *resource.EnvironmentReference.Id)
+	}
+	if resource.ServiceEndpointId != nil {
+		d.Set("service_endpoint_id", resource.ServiceEndpointId.String())
+	}
+	d.Set("name", converter.ToString(resource.Name, ""))
+	d.Set("namespace", converter.ToString(resource.Namespace, ""))
+	d.Set("cluster_name", converter.ToString(resource.ClusterName, ""))
+}
@@ -0,0 +1,153 @@
+// +build all resource_environment_resource_kubernetes
+// +build !exclude_resource_environment_resource_kubernetes
+
+package taskagent
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKubernetesResourceProjectID = "9083e944-8e9e-405e-960a-c80180aa71e6"
+var testKubernetesResourceServiceEndpointID = uuid.New()
+
+var testKubernetesResource = taskagent.KubernetesResource{
+	Id:   converter.Int(3),
+	Name: converter.String("web"),
+	EnvironmentReference: &taskagent.EnvironmentReference{
+		Id:   converter.Int(12),
+		Name: converter.String("Production"),
+	},
+	Namespace:         converter.String("web"),
+	ClusterName:       converter.String("aks-prod"),
+	ServiceEndpointId: &testKubernetesResourceServiceEndpointID,
+}
+
+// verifies that the Kubernetes resource survives a flatten/expand roundtrip
+func TestEnvironmentResourceKubernetes_FlattenExpand_RoundTrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceEnvironmentResourceKubernetes().Schema, nil)
+	flattenKubernetesResource(resourceData, &testKubernetesResource, testKubernetesResourceProjectID)
+
+	require.Equal(t, "3", resourceData.Id())
+	require.Equal(t, 12, resourceData.Get("environment_id"))
+
+	parameters, err := expandKubernetesResource(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, &taskagent.KubernetesResourceCreateParameters{
+		Name:              testKubernetesResource.Name,
+		Namespace:         testKubernetesResource.Namespace,
+		ClusterName:       testKubernetesResource.ClusterName,
+		ServiceEndpointId: &testKubernetesResourceServiceEndpointID,
+	}, parameters)
+}
+
+func TestEnvironmentResourceKubernetes_Import(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceEnvironmentResourceKubernetes().Schema, nil)
+	resourceData.SetId(testKubernetesResourceProjectID + "/12/3")
+	imported, err := importEnvironmentResourceKubernetes(resourceData, nil)
+	require.Nil(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, "3", resourceData.Id())
+	require.Equal(t, testKubernetesResourceProjectID, resourceData.Get("project_id"))
+	require.Equal(t, 12, resourceData.Get("environment_id"))
+
+	for _, id := range []string{testKubernetesResourceProjectID + "/3", testKubernetesResourceProjectID + "/env/3", testKubernetesResourceProjectID + "/12/"} {
+		resourceData.SetId(id)
+		_, err = importEnvironmentResourceKubernetes(resourceData, nil)
+		require.NotNil(t, err, id)
+	}
+}
+
+func getTestKubernetesResourceClients(t *testing.T, handler http.HandlerFunc) (*client.AggregatedClient, *testhelper.RestServer) {
+	server := testhelper.NewRestServer(t, map[uuid.UUID]string{
+		kubernetesResourcesLocationID: "{project}/_apis/distributedtask/environments/{environmentId}/providers/kubernetes/{resourceId}",
+	}, handler)
+	clients := &client.AggregatedClient{
+		TaskAgentClient: &taskagent.ClientImpl{Client: *server.Client},
+		Ctx:             context.Background(),
+	}
+	return clients, server
+}
+
+// verifies that the Kubernetes resource is added to the configured environment and read back afterwards
+func TestEnvironmentResourceKubernetes_Create_SendsResource(t *testing.T) {
+	resourcesPath := "/" + testKubernetesResourceProjectID + "/_apis/distributedtask/environments/12/providers/kubernetes"
+	clients, server := getTestKubernetesResourceClients(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == resourcesPath:
+			var parameters taskagent.KubernetesResourceCreateParameters
+			testhelper.ReadRestRequestBody(t, r, &parameters)
+			assert.Equal(t, "web", *parameters.Name)
+			assert.Equal(t, "aks-prod", *parameters.ClusterName)
+			assert.Equal(t, testKubernetesResourceServiceEndpointID, *parameters.ServiceEndpointId)
+			testhelper.WriteRestResponse(t, w, http.StatusOK, testKubernetesResource)
+		case r.Method == http.MethodGet && r.URL.Path == resourcesPath+"/3":
+			testhelper.WriteRestResponse(t, w, http.StatusOK, testKubernetesResource)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	defer server.Close()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceEnvironmentResourceKubernetes().Schema, map[string]interface{}{
+		"project_id":          testKubernetesResourceProjectID,
+		"environment_id":      12,
+		"service_endpoint_id": testKubernetesResourceServiceEndpointID.String(),
+		"name":                "web",
+		"namespace":           "web",
+		"cluster_name":        "aks-prod",
+	})
+	err := resourceEnvironmentResourceKubernetesCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "3", resourceData.Id())
+}
+
+// verifies that a Kubernetes resource removed outside of Terraform is removed from the state
+func TestEnvironmentResourceKubernetes_Read_RemovesDeletedResource(t *testing.T) {
+	clients, server := getTestKubernetesResourceClients(t, func(w http.ResponseWriter, r *http.Request) {
+		testhelper.WriteRestError(t, w, http.StatusNotFound, "Resource 3 not found")
+	})
+	defer server.Close()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceEnvironmentResourceKubernetes().Schema, nil)
+	flattenKubernetesResource(resourceData, &testKubernetesResource, testKubernetesResourceProjectID)
+
+	err := resourceEnvironmentResourceKubernetesRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+func TestEnvironmentResourceKubernetes_DoesNotSwallowError(t *testing.T) {
+	functionsUnderTest := map[string]func(*schema.ResourceData, interface{}) error{
+		"Create": resourceEnvironmentResourceKubernetesCreate,
+		"Read":   resourceEnvironmentResourceKubernetesRead,
+		"Delete": resourceEnvironmentResourceKubernetesDelete,
+	}
+
+	for name, functionUnderTest := range functionsUnderTest {
+		t.Run(name, func(t *testing.T) {
+			clients, server := getTestKubernetesResourceClients(t, func(w http.ResponseWriter, r *http.Request) {
+				testhelper.WriteRestError(t, w, http.StatusInternalServerError, name+"KubernetesResource() Failed")
+			})
+			defer server.Close()
+
+			resourceData := schema.TestResourceDataRaw(t, ResourceEnvironmentResourceKubernetes().Schema, nil)
+			flattenKubernetesResource(resourceData, &testKubernetesResource, testKubernetesResourceProjectID)
+
+			err := functionUnderTest(resourceData, clients)
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), name+"KubernetesResource() Failed")
+		})
+	}
+}
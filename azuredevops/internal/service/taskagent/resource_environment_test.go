@@ -0,0 +1,143 @@
+// +build all resource_environment
+// +build !exclude_resource_environment
+
+package taskagent
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEnvironmentProjectID = "9083e944-8e9e-405e-960a-c80180aa71e6"
+
+var testEnvironment = taskagent.EnvironmentInstance{
+	Id:          converter.Int(12),
+	Name:        converter.String("Production"),
+	Description: converter.String("Production environment"),
+}
+
+// verifies that the attributes of an environment are flattened into the resource data
+func TestEnvironment_Flatten(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceEnvironment().Schema, nil)
+	flattenEnvironment(resourceData, &testEnvironment, testEnvironmentProjectID)
+
+	require.Equal(t, "12", resourceData.Id())
+	require.Equal(t, testEnvironmentProjectID, resourceData.Get("project_id"))
+	require.Equal(t, "Production", resourceData.Get("name"))
+	require.Equal(t, "Production environment", resourceData.Get("description"))
+}
+
+func getTestEnvironmentClients(t *testing.T, handler http.HandlerFunc) (*client.AggregatedClient, *testhelper.RestServer) {
+	server := testhelper.NewRestServer(t, map[uuid.UUID]string{
+		environmentsLocationID: "{project}/_apis/distributedtask/environments/{environmentId}",
+	}, handler)
+	clients := &client.AggregatedClient{
+		TaskAgentClient: &taskagent.ClientImpl{Client: *server.Client},
+		Ctx:             context.Background(),
+	}
+	return clients, server
+}
+
+// verifies that the environment is created with the configured name and description and read back afterwards
+func TestEnvironment_Create_SendsEnvironment(t *testing.T) {
+	clients, server := getTestEnvironmentClients(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/"+testEnvironmentProjectID+"/_apis/distributedtask/environments":
+			var parameters taskagent.EnvironmentCreateParameter
+			testhelper.ReadRestRequestBody(t, r, &parameters)
+			assert.Equal(t, "Production", *parameters.Name)
+			assert.Equal(t, "Production environment", *parameters.Description)
+			testhelper.WriteRestResponse(t, w, http.StatusOK, testEnvironment)
+		case r.Method == http.MethodGet && r.URL.Path == "/"+testEnvironmentProjectID+"/_apis/distributedtask/environments/12":
+			testhelper.WriteRestResponse(t, w, http.StatusOK, testEnvironment)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	defer server.Close()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceEnvironment().Schema, map[string]interface{}{
+		"project_id":  testEnvironmentProjectID,
+		"name":        "Production",
+		"description": "Production environment",
+	})
+	err := resourceEnvironmentCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "12", resourceData.Id())
+}
+
+// verifies that the name and description of an environment are updated in place
+func TestEnvironment_Update_SendsEnvironment(t *testing.T) {
+	updatedEnvironment := testEnvironment
+	updatedEnvironment.Name = converter.String("Staging")
+	clients, server := getTestEnvironmentClients(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+testEnvironmentProjectID+"/_apis/distributedtask/environments/12", r.URL.Path)
+		if r.Method == http.MethodPatch {
+			var parameters taskagent.EnvironmentUpdateParameter
+			testhelper.ReadRestRequestBody(t, r, &parameters)
+			assert.Equal(t, "Staging", *parameters.Name)
+			assert.Equal(t, "Production environment", *parameters.Description)
+		}
+		testhelper.WriteRestResponse(t, w, http.StatusOK, updatedEnvironment)
+	})
+	defer server.Close()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceEnvironment().Schema, nil)
+	flattenEnvironment(resourceData, &testEnvironment, testEnvironmentProjectID)
+	resourceData.Set("name", "Staging")
+
+	err := resourceEnvironmentUpdate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "Staging", resourceData.Get("name"))
+}
+
+// verifies that an environment deleted outside of Terraform is removed from the state
+func TestEnvironment_Read_RemovesDeletedEnvironment(t *testing.T) {
+	clients, server := getTestEnvironmentClients(t, func(w http.ResponseWriter, r *http.Request) {
+		testhelper.WriteRestError(t, w, http.StatusNotFound, "Environment 12 not found")
+	})
+	defer server.Close()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceEnvironment().Schema, nil)
+	flattenEnvironment(resourceData, &testEnvironment, testEnvironmentProjectID)
+
+	err := resourceEnvironmentRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+func TestEnvironment_DoesNotSwallowError(t *testing.T) {
+	functionsUnderTest := map[string]func(*schema.ResourceData, interface{}) error{
+		"Create": resourceEnvironmentCreate,
+		"Read":   resourceEnvironmentRead,
+		"Update": resourceEnvironmentUpdate,
+		"Delete": resourceEnvironmentDelete,
+	}
+
+	for name, functionUnderTest := range functionsUnderTest {
+		t.Run(name, func(t *testing.T) {
+			clients, server := getTestEnvironmentClients(t, func(w http.ResponseWriter, r *http.Request) {
+				testhelper.WriteRestError(t, w, http.StatusInternalServerError, name+"Environment() Failed")
+			})
+			defer server.Close()
+
+			resourceData := schema.TestResourceDataRaw(t, ResourceEnvironment().Schema, nil)
+			flattenEnvironment(resourceData, &testEnvironment, testEnvironmentProjectID)
+
+			err := functionUnderTest(resourceData, clients)
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), name+"Environment() Failed")
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package taskagent
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/secretmemo"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+const (
+	sfSourceFile    = "source_file"
+	sfContentBase64 = "content_base64"
+	sfContentHash   = "content_hash"
+	sfAllowAccess   = "allow_access"
+)
+
+// ResourceSecureFile schema and implementation for secure file resource
+func ResourceSecureFile() *schema.Resource {
+	// Note: the content of a secure file can not be updated, so a changed content requires a new resource.
+	// The content itself is never stored in the state, only a bcrypted hash of its SHA-256 digest.
+	return &schema.Resource{
+		Create:        resourceSecureFileCreate,
+		Read:          resourceSecureFileRead,
+		Update:        resourceSecureFileUpdate,
+		Delete:        resourceSecureFileDelete,
+		CustomizeDiff: customizeSecureFileDiff,
+		Importer:      tfhelper.ImportProjectQualifiedResourceUUID(),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			sfSourceFile: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ExactlyOneOf: []string{sfSourceFile, sfContentBase64},
+			},
+			sfContentBase64: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Sensitive:        true,
+				ValidateFunc:     validation.StringIsBase64,
+				DiffSuppressFunc: suppressUnchangedSecureFileContent,
+				ExactlyOneOf:     []string{sfSourceFile, sfContentBase64},
+			},
+			sfContentHash: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "A bcrypted hash of the SHA-256 digest of the secure file content",
+			},
+			"properties": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			sfAllowAccess: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceSecureFileCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	content, err := expandSecureFileContent(d)
+	if err != nil {
+		return err
+	}
+
+	secureFile, err := uploadSecureFile(clients, projectID, d.Get("name").(string), content)
+	if err != nil {
+		return fmt.Errorf("Error uploading secure file in project %s: %+v", projectID, err)
+	}
+	d.SetId(secureFile.Id.String())
+
+	_, contentMemo, err := secretmemo.IsUpdating(calcSecureFileDigest(content), "")
+	if err != nil {
+		return fmt.Errorf("Error hashing the content of secure file %s: %+v", d.Id(), err)
+	}
+	d.Set(sfContentHash, contentMemo)
+	d.Set(sfContentBase64, "")
+
+	if properties := d.Get("properties").(map[string]interface{}); len(properties) > 0 {
+		_, err = updateSecureFile(clients, projectID, expandSecureFile(d, secureFile.Id))
+		if err != nil {
+			return fmt.Errorf("Error updating properties of secure file %s in project %s: %+v", d.Id(), projectID, err)
+		}
+	}
+
+	_, err = updateDefinitionResourceAuth(clients, expandSecureFileAllowAccess(d), &projectID)
+	if err != nil {
+		return fmt.Errorf("Error creating definitionResourceReference Azure DevOps object: %+v", err)
+	}
+
+	return resourceSecureFileRead(d, m)
+}
+
+func resourceSecureFileRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	secureFileID := d.Id()
+
+	secureFile, err := getSecureFile(clients, projectID, secureFileID)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading secure file %s in project %s: %+v", secureFileID, projectID, err)
+	}
+	if secureFile.Id == nil {
+		d.SetId("")
+		return nil
+	}
+
+	flattenSecureFile(d, secureFile, projectID)
+
+	//Read the Authorization Resource for get allow access property
+	resourceRefType := "securefile"
+	projectResources, err := clients.BuildClient.GetProjectResources(
+		clients.Ctx,
+		build.GetProjectResourcesArgs{
+			Project: &projectID,
+			Type:    &resourceRefType,
+			Id:      &secureFileID,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("Error looking up project resources given ID (%v) and project ID (%v): %v", secureFileID, projectID, err)
+	}
+
+	flattenAllowAccess(d, projectResources)
+	return nil
+}
+
+func resourceSecureFileUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	if d.HasChanges("name", "properties") {
+		secureFileID, err := uuid.Parse(d.Id())
+		if err != nil {
+			return fmt.Errorf("Error parsing secure file ID %s: %+v", d.Id(), err)
+		}
+
+		_, err = updateSecureFile(clients, projectID, expandSecureFile(d, &secureFileID))
+		if err != nil {
+			return fmt.Errorf("Error updating secure file %s in project %s: %+v", d.Id(), projectID, err)
+		}
+	}
+
+	if d.HasChange(sfAllowAccess) {
+		_, err := updateDefinitionResourceAuth(clients, expandSecureFileAllowAccess(d), &projectID)
+		if err != nil {
+			return fmt.Errorf("Error updating definitionResourceReference Azure DevOps object: %+v", err)
+		}
+	}
+
+	return resourceSecureFileRead(d, m)
+}
+
+func resourceSecureFileDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	err := deleteSecureFile(clients, projectID, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting secure file %s in project %s: %+v", d.Id(), projectID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// customizeSecureFileDiff forces a new secure file if the content of the configured source file changed since the upload
+func customizeSecureFileDiff(d *schema.ResourceDiff, m interface{}) error {
+	sourceFile := d.Get(sfSourceFile).(string)
+	contentMemo := d.Get(sfContentHash).(string)
+	if d.Id() == "" || sourceFile == "" || contentMemo == "" || d.HasChange(sfSourceFile) {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(sourceFile)
+	if err != nil {
+		return fmt.Errorf("Error reading secure file content from %s: %+v", sourceFile, err)
+	}
+
+	isUpdating, _, err := secretmemo.IsUpdating(calcSecureFileDigest(content), contentMemo)
+	if err != nil {
+		return fmt.Errorf("Error hashing secure file content from %s: %+v", sourceFile, err)
+	}
+	if !isUpdating {
+		return nil
+	}
+
+	if err := d.SetNewComputed(sfContentHash); err != nil {
+		return err
+	}
+	return d.ForceNew(sfContentHash)
+}
+
+// suppressUnchangedSecureFileContent suppresses the diff of the base64 content, which is not stored in the state,
+// as long as it matches the hash of the uploaded content
+func suppressUnchangedSecureFileContent(k, old, new string, d *schema.ResourceData) bool {
+	content, err := base64.StdEncoding.DecodeString(new)
+	if err != nil {
+		return false
+	}
+
+	isUpdating, _, err := secretmemo.IsUpdating(calcSecureFileDigest(content), d.Get(sfContentHash).(string))
+	return err == nil && !isUpdating
+}
+
+// calcSecureFileDigest returns the hex encoded SHA-256 digest of the content. The digest, rather than the content,
+// is memoized because bcrypt only considers the first 72 bytes of its input.
+func calcSecureFileDigest(content []byte) string {
+	digest := sha256.Sum256(content)
+	return hex.EncodeToString(digest[:])
+}
+
+func expandSecureFileContent(d *schema.ResourceData) ([]byte, error) {
+	if sourceFile, ok := d.GetOk(sfSourceFile); ok {
+		content, err := ioutil.ReadFile(sourceFile.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error reading secure file content from %s: %+v", sourceFile, err)
+		}
+		return content, nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(d.Get(sfContentBase64).(string))
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding base64 secure file content: %+v", err)
+	}
+	return content, nil
+}
+
+func expandSecureFile(d *schema.ResourceData, secureFileID *uuid.UUID) *taskagent.SecureFile {
+	properties := map[string]string{}
+	for key, value := range d.Get("properties").(map[string]interface{}) {
+		properties[key] = value.(string)
+	}
+
+	return &taskagent.SecureFile{
+		Id:         secureFileID,
+		Name:       converter.String(d.Get("name").(string)),
+		Properties: &properties,
+	}
+}
+
+// Convert internal Terraform data structure to an AzDO data structure for Allow Access
+func expandSecureFileAllowAccess(d *schema.ResourceData) []build.DefinitionResourceReference {
+	resourceRefType := "securefile"
+	secureFileID := d.Id()
+
+	return []build.DefinitionResourceReference{{
+		Type:       &resourceRefType,
+		Authorized: converter.Bool(d.Get(sfAllowAccess).(bool)),
+		Name:       converter.String(d.Get("name").(string)),
+		Id:         &secureFileID,
+	}}
+}
+
+func flattenSecureFile(d *schema.ResourceData, secureFile *taskagent.SecureFile, projectID string) {
+	d.SetId(secureFile.Id.String())
+	d.Set("project_id", projectID)
+	d.Set("name", converter.ToString(secureFile.Name, ""))
+
+	properties := map[string]interface{}{}
+	if secureFile.Properties != nil {
+		for key, value := range *secureFile.Properties {
+			properties[key] = value
+		}
+	}
+	d.Set("properties", properties)
+}
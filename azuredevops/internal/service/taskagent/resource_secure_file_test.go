@@ -0,0 +1,303 @@
+// +build all resource_secure_file
+// +build !exclude_resource_secure_file
+
+package taskagent
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/secretmemo"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecureFileProjectID = "9083e944-8e9e-405e-960a-c80180aa71e6"
+var testSecureFileID = uuid.MustParse("4b7a2a7c-0b5d-4a4e-9d6c-3d6f8b8f2d11")
+
+var testSecureFile = taskagent.SecureFile{
+	Id:   &testSecureFileID,
+	Name: converter.String("signing.p12"),
+	Properties: &map[string]string{
+		"environment": "production",
+	},
+}
+
+// verifies that the attributes of a secure file are flattened into the resource data
+func TestSecureFile_Flatten(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, nil)
+	flattenSecureFile(resourceData, &testSecureFile, testSecureFileProjectID)
+
+	require.Equal(t, testSecureFileID.String(), resourceData.Id())
+	require.Equal(t, testSecureFileProjectID, resourceData.Get("project_id"))
+	require.Equal(t, "signing.p12", resourceData.Get("name"))
+	require.Equal(t, map[string]interface{}{"environment": "production"}, resourceData.Get("properties"))
+}
+
+// verifies that name and properties are expanded into the secure file
+func TestSecureFile_Expand(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, nil)
+	flattenSecureFile(resourceData, &testSecureFile, testSecureFileProjectID)
+
+	secureFile := expandSecureFile(resourceData, &testSecureFileID)
+	require.Equal(t, testSecureFile, *secureFile)
+}
+
+// verifies that the content is read from the base64 attribute or the source file
+func TestSecureFile_ExpandContent(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, map[string]interface{}{
+		sfContentBase64: "c2VjcmV0",
+	})
+	content, err := expandSecureFileContent(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, []byte("secret"), content)
+
+	sourceFile, err := ioutil.TempFile("", "securefile")
+	require.Nil(t, err)
+	defer os.Remove(sourceFile.Name())
+	_, err = sourceFile.WriteString("secret from file")
+	require.Nil(t, err)
+	require.Nil(t, sourceFile.Close())
+
+	resourceData = schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, map[string]interface{}{
+		sfSourceFile: sourceFile.Name(),
+	})
+	content, err = expandSecureFileContent(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, []byte("secret from file"), content)
+}
+
+// verifies that a base64 content is only considered changed if it does not match the memoized content hash
+func TestSecureFile_SuppressUnchangedContent(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, nil)
+	_, contentMemo, err := secretmemo.IsUpdating(calcSecureFileDigest([]byte("secret")), "")
+	require.Nil(t, err)
+	resourceData.Set(sfContentHash, contentMemo)
+
+	require.True(t, suppressUnchangedSecureFileContent(sfContentBase64, "", "c2VjcmV0", resourceData))
+	require.False(t, suppressUnchangedSecureFileContent(sfContentBase64, "", "b3RoZXI=", resourceData))
+	require.False(t, suppressUnchangedSecureFileContent(sfContentBase64, "", "not base64", resourceData))
+}
+
+// getTestSecureFileClients returns clients which send the secure files to a fake service and the authorizations to a mocked build client
+func getTestSecureFileClients(t *testing.T, ctrl *gomock.Controller, handler http.HandlerFunc) (*client.AggregatedClient, *azdosdkmocks.MockBuildClient, *testhelper.RestServer) {
+	server := testhelper.NewRestServer(t, map[uuid.UUID]string{
+		secureFilesLocationID: "{project}/_apis/distributedtask/securefiles/{secureFileId}",
+	}, handler)
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{
+		BuildClient:     buildClient,
+		TaskAgentClient: &taskagent.ClientImpl{Client: *server.Client},
+		Ctx:             context.Background(),
+	}
+	return clients, buildClient, server
+}
+
+func getTestSecureFileAuthorization(authorized bool) []build.DefinitionResourceReference {
+	return []build.DefinitionResourceReference{{
+		Type:       converter.String("securefile"),
+		Authorized: converter.Bool(authorized),
+		Name:       converter.String("signing.p12"),
+		Id:         converter.String(testSecureFileID.String()),
+	}}
+}
+
+// verifies that the content is uploaded, the properties are set afterwards and the secure file is authorized for all pipelines
+func TestSecureFile_Create_UploadsContentAndProperties(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secureFilesPath := "/" + testSecureFileProjectID + "/_apis/distributedtask/securefiles"
+	clients, buildClient, server := getTestSecureFileClients(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == secureFilesPath:
+			assert.Equal(t, "signing.p12", r.URL.Query().Get("name"))
+			assert.Contains(t, r.Header.Get("Content-Type"), "application/octet-stream")
+			content, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("secret"), content)
+			testhelper.WriteRestResponse(t, w, http.StatusOK, taskagent.SecureFile{Id: &testSecureFileID, Name: testSecureFile.Name})
+		case r.Method == http.MethodPatch && r.URL.Path == secureFilesPath+"/"+testSecureFileID.String():
+			var secureFile taskagent.SecureFile
+			testhelper.ReadRestRequestBody(t, r, &secureFile)
+			assert.Equal(t, testSecureFile.Name, secureFile.Name)
+			assert.Equal(t, testSecureFile.Properties, secureFile.Properties)
+			testhelper.WriteRestResponse(t, w, http.StatusOK, testSecureFile)
+		case r.Method == http.MethodGet && r.URL.Path == secureFilesPath+"/"+testSecureFileID.String():
+			testhelper.WriteRestResponse(t, w, http.StatusOK, testSecureFile)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	defer server.Close()
+
+	authorization := getTestSecureFileAuthorization(true)
+	buildClient.
+		EXPECT().
+		AuthorizeProjectResources(clients.Ctx, build.AuthorizeProjectResourcesArgs{
+			Resources: &authorization,
+			Project:   converter.String(testSecureFileProjectID),
+		}).
+		Return(&authorization, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetProjectResources(clients.Ctx, build.GetProjectResourcesArgs{
+			Project: converter.String(testSecureFileProjectID),
+			Type:    converter.String("securefile"),
+			Id:      converter.String(testSecureFileID.String()),
+		}).
+		Return(&authorization, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, map[string]interface{}{
+		"project_id":    testSecureFileProjectID,
+		"name":          "signing.p12",
+		sfContentBase64: "c2VjcmV0",
+		"properties":    map[string]interface{}{"environment": "production"},
+		sfAllowAccess:   true,
+	})
+	err := resourceSecureFileCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testSecureFileID.String(), resourceData.Id())
+	require.Equal(t, "", resourceData.Get(sfContentBase64))
+	require.NotEmpty(t, resourceData.Get(sfContentHash))
+	require.True(t, resourceData.Get(sfAllowAccess).(bool))
+}
+
+// verifies that a failing authorization of the uploaded secure file is reported
+func TestSecureFile_Create_DoesNotSwallowAuthorizationError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, buildClient, server := getTestSecureFileClients(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		testhelper.WriteRestResponse(t, w, http.StatusOK, taskagent.SecureFile{Id: &testSecureFileID, Name: testSecureFile.Name})
+	})
+	defer server.Close()
+
+	buildClient.
+		EXPECT().
+		AuthorizeProjectResources(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("AuthorizeProjectResources() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, map[string]interface{}{
+		"project_id":    testSecureFileProjectID,
+		"name":          "signing.p12",
+		sfContentBase64: "c2VjcmV0",
+	})
+	err := resourceSecureFileCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "AuthorizeProjectResources() Failed")
+}
+
+// verifies that a rename and the granted authorization are sent as update of the existing secure file
+func TestSecureFile_Update_SendsNameAndAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renamedSecureFile := testSecureFile
+	renamedSecureFile.Name = converter.String("renamed.p12")
+	clients, buildClient, server := getTestSecureFileClients(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+testSecureFileProjectID+"/_apis/distributedtask/securefiles/"+testSecureFileID.String(), r.URL.Path)
+		if r.Method == http.MethodPatch {
+			var secureFile taskagent.SecureFile
+			testhelper.ReadRestRequestBody(t, r, &secureFile)
+			assert.Equal(t, testSecureFileID, *secureFile.Id)
+			assert.Equal(t, "renamed.p12", *secureFile.Name)
+		}
+		testhelper.WriteRestResponse(t, w, http.StatusOK, renamedSecureFile)
+	})
+	defer server.Close()
+
+	authorization := getTestSecureFileAuthorization(true)
+	authorization[0].Name = converter.String("renamed.p12")
+	buildClient.
+		EXPECT().
+		AuthorizeProjectResources(clients.Ctx, build.AuthorizeProjectResourcesArgs{
+			Resources: &authorization,
+			Project:   converter.String(testSecureFileProjectID),
+		}).
+		Return(&authorization, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetProjectResources(clients.Ctx, gomock.Any()).
+		Return(&authorization, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, map[string]interface{}{
+		"project_id":  testSecureFileProjectID,
+		"name":        "renamed.p12",
+		"properties":  map[string]interface{}{"environment": "production"},
+		sfAllowAccess: true,
+	})
+	resourceData.SetId(testSecureFileID.String())
+
+	err := resourceSecureFileUpdate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "renamed.p12", resourceData.Get("name"))
+	require.True(t, resourceData.Get(sfAllowAccess).(bool))
+}
+
+// verifies that a secure file deleted outside of Terraform is removed from the state
+func TestSecureFile_Read_RemovesDeletedSecureFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, _, server := getTestSecureFileClients(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		testhelper.WriteRestError(t, w, http.StatusNotFound, "Secure file not found")
+	})
+	defer server.Close()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, nil)
+	flattenSecureFile(resourceData, &testSecureFile, testSecureFileProjectID)
+
+	err := resourceSecureFileRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+func TestSecureFile_DoesNotSwallowError(t *testing.T) {
+	functionsUnderTest := map[string]func(*schema.ResourceData, interface{}) error{
+		"Create": resourceSecureFileCreate,
+		"Read":   resourceSecureFileRead,
+		"Update": resourceSecureFileUpdate,
+		"Delete": resourceSecureFileDelete,
+	}
+
+	for name, functionUnderTest := range functionsUnderTest {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			clients, _, server := getTestSecureFileClients(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+				testhelper.WriteRestError(t, w, http.StatusInternalServerError, name+"SecureFile() Failed")
+			})
+			defer server.Close()
+
+			resourceData := schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, map[string]interface{}{
+				sfContentBase64: "c2VjcmV0",
+			})
+			flattenSecureFile(resourceData, &testSecureFile, testSecureFileProjectID)
+
+			err := functionUnderTest(resourceData, clients)
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), name+"SecureFile() Failed")
+		})
+	}
+}
@@ -0,0 +1,622 @@
+package taskagent
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+const (
+	tgMajorVersion = "major_version"
+	tgPreview      = "preview"
+)
+
+// The runs on values of a task group created in the web UI
+var defaultTaskGroupRunsOn = []string{"Agent", "DeploymentGroup"}
+
+// ResourceTaskGroup schema and implementation for task group resource. The resource manages the latest
+// major version of a task group, a new major version is published as soon as the major_version is increased.
+func ResourceTaskGroup() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceTaskGroupCreate,
+		Read:          resourceTaskGroupRead,
+		Update:        resourceTaskGroupUpdate,
+		Delete:        resourceTaskGroupDelete,
+		CustomizeDiff: customizeTaskGroupDiff,
+		Importer:      tfhelper.ImportProjectQualifiedResourceUUID(),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"category": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Deploy",
+				ValidateFunc: validation.StringInSlice([]string{"Build", "Deploy", "Package", "Utility", "Test"}, false),
+			},
+			"instance_name_format": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"runs_on": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"Agent", "DeploymentGroup", "Server"}, false),
+				},
+			},
+			"input": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "string",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"default_value": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"required": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"help_markdown": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"task": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"task_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+						"version": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"definition_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "task",
+							ValidateFunc: validation.StringInSlice([]string{"task", "metaTask"}, false),
+						},
+						"display_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"always_run": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"continue_on_error": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"condition": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "succeeded()",
+						},
+						"timeout_in_minutes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"inputs": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"environment": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			tgMajorVersion: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			tgPreview: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"revision": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTaskGroupCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	parameter, err := expandTaskGroupCreateParameter(d)
+	if err != nil {
+		return err
+	}
+
+	createdTaskGroup, err := clients.TaskAgentClient.AddTaskGroup(clients.Ctx, taskagent.AddTaskGroupArgs{
+		Project:   converter.String(projectID),
+		TaskGroup: parameter,
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating task group in project %s: %+v", projectID, err)
+	}
+
+	d.SetId(createdTaskGroup.Id.String())
+	return resourceTaskGroupRead(d, m)
+}
+
+func resourceTaskGroupRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	taskGroup, err := getLatestTaskGroup(clients, projectID, d.Id())
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading task group %s in project %s: %+v", d.Id(), projectID, err)
+	}
+	if taskGroup == nil {
+		d.SetId("")
+		return nil
+	}
+
+	return flattenTaskGroup(d, taskGroup, projectID)
+}
+
+func resourceTaskGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	latestTaskGroup, err := getLatestTaskGroup(clients, projectID, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading task group %s in project %s: %+v", d.Id(), projectID, err)
+	}
+	if latestTaskGroup == nil {
+		return fmt.Errorf("Task group %s does not exist in project %s", d.Id(), projectID)
+	}
+
+	if d.HasChange(tgMajorVersion) {
+		err = publishTaskGroupMajorVersion(clients, d, latestTaskGroup)
+		if err != nil {
+			return fmt.Errorf("Error publishing version %d of task group %s in project %s: %+v", d.Get(tgMajorVersion).(int), d.Id(), projectID, err)
+		}
+		return resourceTaskGroupRead(d, m)
+	}
+
+	parameter, err := expandTaskGroupUpdateParameter(d, latestTaskGroup)
+	if err != nil {
+		return err
+	}
+
+	updatedTaskGroup, err := clients.TaskAgentClient.UpdateTaskGroup(clients.Ctx, taskagent.UpdateTaskGroupArgs{
+		Project:     converter.String(projectID),
+		TaskGroupId: latestTaskGroup.Id,
+		TaskGroup:   parameter,
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating task group %s in project %s: %+v", d.Id(), projectID, err)
+	}
+
+	// a major version published as preview is released by publishing the preview
+	if converter.ToBool(updatedTaskGroup.Preview, false) && !d.Get(tgPreview).(bool) {
+		updatedTaskGroup.Preview = converter.Bool(false)
+		_, err = publishPreviewTaskGroup(clients, projectID, updatedTaskGroup, false)
+		if err != nil {
+			return fmt.Errorf("Error releasing preview of task group %s in project %s: %+v", d.Id(), projectID, err)
+		}
+	}
+
+	return resourceTaskGroupRead(d, m)
+}
+
+func resourceTaskGroupDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	taskGroupID, err := uuid.Parse(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing task group ID %s: %+v", d.Id(), err)
+	}
+
+	err = clients.TaskAgentClient.DeleteTaskGroup(clients.Ctx, taskagent.DeleteTaskGroupArgs{
+		Project:     converter.String(projectID),
+		TaskGroupId: &taskGroupID,
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting task group %s in project %s: %+v", d.Id(), projectID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// customizeTaskGroupDiff rejects version changes which cannot be applied. A task group is always created
+// as released version, and only a new major version can be published as preview.
+func customizeTaskGroupDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		if d.Get(tgPreview).(bool) {
+			return fmt.Errorf("A new task group cannot be published as preview, its first major version is always released")
+		}
+		return nil
+	}
+
+	if d.HasChange(tgMajorVersion) {
+		oldMajorVersion, newMajorVersion := d.GetChange(tgMajorVersion)
+		return validateTaskGroupMajorVersionChange(oldMajorVersion.(int), newMajorVersion.(int))
+	}
+
+	if d.HasChange(tgPreview) && d.Get(tgPreview).(bool) {
+		return fmt.Errorf("A released task group cannot be turned into a preview, increase the %s to publish a preview", tgMajorVersion)
+	}
+	return nil
+}
+
+// validateTaskGroupMajorVersionChange only allows the next major version, because publishing a draft
+// always increases the major version of a task group by one
+func validateTaskGroupMajorVersionChange(oldMajorVersion int, newMajorVersion int) error {
+	if newMajorVersion < oldMajorVersion {
+		return fmt.Errorf("The major version of a task group cannot be decreased from %d to %d", oldMajorVersion, newMajorVersion)
+	}
+	if newMajorVersion != oldMajorVersion+1 {
+		return fmt.Errorf("The major version of a task group can only be increased by one, from %d to %d, but not to %d", oldMajorVersion, oldMajorVersion+1, newMajorVersion)
+	}
+	return nil
+}
+
+// publishTaskGroupMajorVersion saves the configured task group as draft of the latest version and publishes the draft as new major version
+func publishTaskGroupMajorVersion(clients *client.AggregatedClient, d *schema.ResourceData, latestTaskGroup *taskagent.TaskGroup) error {
+	projectID := d.Get("project_id").(string)
+
+	parameter, err := expandTaskGroupCreateParameter(d)
+	if err != nil {
+		return err
+	}
+	parameter.ParentDefinitionId = latestTaskGroup.Id
+
+	draftTaskGroup, err := clients.TaskAgentClient.AddTaskGroup(clients.Ctx, taskagent.AddTaskGroupArgs{
+		Project:   converter.String(projectID),
+		TaskGroup: parameter,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = publishTaskGroup(clients, projectID, latestTaskGroup.Id, &taskagent.PublishTaskGroupMetadata{
+		TaskGroupId:              draftTaskGroup.Id,
+		TaskGroupRevision:        draftTaskGroup.Revision,
+		ParentDefinitionRevision: latestTaskGroup.Revision,
+		Preview:                  converter.Bool(d.Get(tgPreview).(bool)),
+	})
+	return err
+}
+
+// getLatestTaskGroup returns the highest major version of a task group, or nil if the task group has been deleted
+func getLatestTaskGroup(clients *client.AggregatedClient, projectID string, taskGroupID string) (*taskagent.TaskGroup, error) {
+	id, err := uuid.Parse(taskGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing task group ID %s: %+v", taskGroupID, err)
+	}
+
+	taskGroups, err := clients.TaskAgentClient.GetTaskGroups(clients.Ctx, taskagent.GetTaskGroupsArgs{
+		Project:     converter.String(projectID),
+		TaskGroupId: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return selectLatestTaskGroup(taskGroups), nil
+}
+
+// selectLatestTaskGroup returns the highest major version which has not been deleted
+func selectLatestTaskGroup(taskGroups *[]taskagent.TaskGroup) *taskagent.TaskGroup {
+	var latestTaskGroup *taskagent.TaskGroup
+	if taskGroups == nil {
+		return nil
+	}
+	for i, taskGroup := range *taskGroups {
+		if taskGroup.Id == nil || converter.ToBool(taskGroup.Deleted, false) {
+			continue
+		}
+		if latestTaskGroup == nil || getTaskGroupMajorVersion(&taskGroup) > getTaskGroupMajorVersion(latestTaskGroup) {
+			latestTaskGroup = &(*taskGroups)[i]
+		}
+	}
+	return latestTaskGroup
+}
+
+func getTaskGroupMajorVersion(taskGroup *taskagent.TaskGroup) int {
+	if taskGroup.Version == nil {
+		return 0
+	}
+	return converter.ToInt(taskGroup.Version.Major, 0)
+}
+
+func expandTaskGroupCreateParameter(d *schema.ResourceData) (*taskagent.TaskGroupCreateParameter, error) {
+	tasks, err := expandTaskGroupSteps(d.Get("task").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	majorVersion := d.Get(tgMajorVersion).(int)
+	if majorVersion == 0 {
+		majorVersion = 1
+	}
+
+	return &taskagent.TaskGroupCreateParameter{
+		Name:               converter.String(d.Get("name").(string)),
+		Description:        converter.String(d.Get("description").(string)),
+		Category:           converter.String(d.Get("category").(string)),
+		InstanceNameFormat: converter.String(expandTaskGroupInstanceNameFormat(d)),
+		RunsOn:             expandTaskGroupRunsOn(d),
+		Inputs:             expandTaskGroupInputs(d.Get("input").([]interface{})),
+		Tasks:              tasks,
+		Version: &taskagent.TaskVersion{
+			Major:  converter.Int(majorVersion),
+			Minor:  converter.Int(0),
+			Patch:  converter.Int(0),
+			IsTest: converter.Bool(false),
+		},
+	}, nil
+}
+
+func expandTaskGroupUpdateParameter(d *schema.ResourceData, latestTaskGroup *taskagent.TaskGroup) (*taskagent.TaskGroupUpdateParameter, error) {
+	tasks, err := expandTaskGroupSteps(d.Get("task").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskagent.TaskGroupUpdateParameter{
+		Id:                 latestTaskGroup.Id,
+		Revision:           latestTaskGroup.Revision,
+		Version:            latestTaskGroup.Version,
+		Name:               converter.String(d.Get("name").(string)),
+		Description:        converter.String(d.Get("description").(string)),
+		Category:           converter.String(d.Get("category").(string)),
+		InstanceNameFormat: converter.String(expandTaskGroupInstanceNameFormat(d)),
+		RunsOn:             expandTaskGroupRunsOn(d),
+		Inputs:             expandTaskGroupInputs(d.Get("input").([]interface{})),
+		Tasks:              tasks,
+	}, nil
+}
+
+func expandTaskGroupInstanceNameFormat(d *schema.ResourceData) string {
+	if instanceNameFormat, ok := d.GetOk("instance_name_format"); ok {
+		return instanceNameFormat.(string)
+	}
+	return fmt.Sprintf("Task group: %s", d.Get("name").(string))
+}
+
+func expandTaskGroupRunsOn(d *schema.ResourceData) *[]string {
+	runsOn := tfhelper.ExpandStringSet(d.Get("runs_on").(*schema.Set))
+	if len(runsOn) == 0 {
+		runsOn = defaultTaskGroupRunsOn
+	}
+	return &runsOn
+}
+
+func expandTaskGroupInputs(configured []interface{}) *[]taskagent.TaskInputDefinition {
+	inputs := []taskagent.TaskInputDefinition{}
+	for _, raw := range configured {
+		input := raw.(map[string]interface{})
+		label := input["label"].(string)
+		if label == "" {
+			label = input["name"].(string)
+		}
+		inputs = append(inputs, taskagent.TaskInputDefinition{
+			Name:         converter.String(input["name"].(string)),
+			Label:        converter.String(label),
+			Type:         converter.String(input["type"].(string)),
+			DefaultValue: converter.String(input["default_value"].(string)),
+			Required:     converter.Bool(input["required"].(bool)),
+			HelpMarkDown: converter.String(input["help_markdown"].(string)),
+		})
+	}
+	return &inputs
+}
+
+func expandTaskGroupSteps(configured []interface{}) (*[]taskagent.TaskGroupStep, error) {
+	steps := []taskagent.TaskGroupStep{}
+	for _, raw := range configured {
+		step := raw.(map[string]interface{})
+		taskID, err := uuid.Parse(step["task_id"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing task ID %s: %+v", step["task_id"].(string), err)
+		}
+
+		steps = append(steps, taskagent.TaskGroupStep{
+			Task: &taskagent.TaskDefinitionReference{
+				Id:             &taskID,
+				VersionSpec:    converter.String(step["version"].(string)),
+				DefinitionType: converter.String(step["definition_type"].(string)),
+			},
+			DisplayName:      converter.String(step["display_name"].(string)),
+			Enabled:          converter.Bool(step["enabled"].(bool)),
+			AlwaysRun:        converter.Bool(step["always_run"].(bool)),
+			ContinueOnError:  converter.Bool(step["continue_on_error"].(bool)),
+			Condition:        converter.String(step["condition"].(string)),
+			TimeoutInMinutes: converter.Int(step["timeout_in_minutes"].(int)),
+			Inputs:           expandStringMap(step["inputs"].(map[string]interface{})),
+			Environment:      expandStringMap(step["environment"].(map[string]interface{})),
+		})
+	}
+	return &steps, nil
+}
+
+func expandStringMap(configured map[string]interface{}) *map[string]string {
+	result := map[string]string{}
+	for key, value := range configured {
+		result[key] = value.(string)
+	}
+	return &result
+}
+
+func flattenTaskGroup(d *schema.ResourceData, taskGroup *taskagent.TaskGroup, projectID string) error {
+	d.SetId(taskGroup.Id.String())
+	d.Set("project_id", projectID)
+	d.Set("name", converter.ToString(taskGroup.Name, ""))
+	d.Set("description", converter.ToString(taskGroup.Description, ""))
+	d.Set("category", converter.ToString(taskGroup.Category, ""))
+	d.Set("instance_name_format", converter.ToString(taskGroup.InstanceNameFormat, ""))
+	d.Set(tgPreview, converter.ToBool(taskGroup.Preview, false))
+	d.Set("revision", converter.ToInt(taskGroup.Revision, 0))
+
+	runsOn := []interface{}{}
+	if taskGroup.RunsOn != nil {
+		for _, value := range *taskGroup.RunsOn {
+			runsOn = append(runsOn, value)
+		}
+	}
+	if err := d.Set("runs_on", runsOn); err != nil {
+		return fmt.Errorf("Error setting runs_on of task group %s: %+v", d.Id(), err)
+	}
+
+	if err := d.Set("input", flattenTaskGroupInputs(taskGroup.Inputs)); err != nil {
+		return fmt.Errorf("Error setting inputs of task group %s: %+v", d.Id(), err)
+	}
+	if err := d.Set("task", flattenTaskGroupSteps(taskGroup.Tasks)); err != nil {
+		return fmt.Errorf("Error setting tasks of task group %s: %+v", d.Id(), err)
+	}
+
+	if taskGroup.Version != nil {
+		d.Set(tgMajorVersion, converter.ToInt(taskGroup.Version.Major, 0))
+		d.Set("version", fmt.Sprintf("%d.%d.%d",
+			converter.ToInt(taskGroup.Version.Major, 0),
+			converter.ToInt(taskGroup.Version.Minor, 0),
+			converter.ToInt(taskGroup.Version.Patch, 0)))
+	}
+	return nil
+}
+
+func flattenTaskGroupInputs(inputs *[]taskagent.TaskInputDefinition) []interface{} {
+	result := []interface{}{}
+	if inputs == nil {
+		return result
+	}
+	for _, input := range *inputs {
+		result = append(result, map[string]interface{}{
+			"name":          converter.ToString(input.Name, ""),
+			"label":         converter.ToString(input.Label, ""),
+			"type":          converter.ToString(input.Type, ""),
+			"default_value": converter.ToString(input.DefaultValue, ""),
+			"required":      converter.ToBool(input.Required, false),
+			"help_markdown": converter.ToString(input.HelpMarkDown, ""),
+		})
+	}
+	return result
+}
+
+func flattenTaskGroupSteps(steps *[]taskagent.TaskGroupStep) []interface{} {
+	result := []interface{}{}
+	if steps == nil {
+		return result
+	}
+	for _, step := range *steps {
+		flattened := map[string]interface{}{
+			"display_name":       converter.ToString(step.DisplayName, ""),
+			"enabled":            converter.ToBool(step.Enabled, false),
+			"always_run":         converter.ToBool(step.AlwaysRun, false),
+			"continue_on_error":  converter.ToBool(step.ContinueOnError, false),
+			"condition":          converter.ToString(step.Condition, ""),
+			"timeout_in_minutes": converter.ToInt(step.TimeoutInMinutes, 0),
+			"inputs":             flattenStringMap(step.Inputs),
+			"environment":        flattenStringMap(step.Environment),
+		}
+		if step.Task != nil {
+			if step.Task.Id != nil {
+				flattened["task_id"] = step.Task.Id.String()
+			}
+			flattened["version"] = converter.ToString(step.Task.VersionSpec, "")
+			flattened["definition_type"] = converter.ToString(step.Task.DefinitionType, "")
+		}
+		result = append(result, flattened)
+	}
+	return result
+}
+
+func flattenStringMap(values *map[string]string) map[string]interface{} {
+	result := map[string]interface{}{}
+	if values == nil {
+		return result
+	}
+	for key, value := range *values {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,353 @@
+// +build all resource_task_group data_sources data_task_group
+// +build !exclude_resource_task_group
+
+package taskagent
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTaskGroupProjectID = "9083e944-8e9e-405e-960a-c80180aa71e6"
+var testTaskGroupID = uuid.MustParse("d8f0e8a4-5c1d-4c55-b1e6-49a1c5e6c3a2")
+var testTaskGroupTaskID = uuid.MustParse("e213ff0f-5d5c-4791-802d-52ea3e7be1f1")
+
+func getTestTaskGroup(majorVersion int) taskagent.TaskGroup {
+	return taskagent.TaskGroup{
+		Id:                 &testTaskGroupID,
+		Name:               converter.String("Deploy Web App"),
+		Description:        converter.String("Deploys the web app"),
+		Category:           converter.String("Deploy"),
+		InstanceNameFormat: converter.String("Task group: Deploy Web App"),
+		RunsOn:             &[]string{"Agent", "DeploymentGroup"},
+		Preview:            converter.Bool(false),
+		Revision:           converter.Int(3),
+		Version: &taskagent.TaskVersion{
+			Major:  converter.Int(majorVersion),
+			Minor:  converter.Int(2),
+			Patch:  converter.Int(0),
+			IsTest: converter.Bool(false),
+		},
+		Inputs: &[]taskagent.TaskInputDefinition{{
+			Name:         converter.String("AppName"),
+			Label:        converter.String("App name"),
+			Type:         converter.String("string"),
+			DefaultValue: converter.String("web"),
+			Required:     converter.Bool(true),
+			HelpMarkDown: converter.String("The name of the web app"),
+		}},
+		Tasks: &[]taskagent.TaskGroupStep{{
+			Task: &taskagent.TaskDefinitionReference{
+				Id:             &testTaskGroupTaskID,
+				VersionSpec:    converter.String("2.*"),
+				DefinitionType: converter.String("task"),
+			},
+			DisplayName:      converter.String("Run script"),
+			Enabled:          converter.Bool(true),
+			AlwaysRun:        converter.Bool(false),
+			ContinueOnError:  converter.Bool(false),
+			Condition:        converter.String("succeeded()"),
+			TimeoutInMinutes: converter.Int(5),
+			Inputs:           &map[string]string{"script": "echo $(AppName)"},
+			Environment:      &map[string]string{"TARGET": "production"},
+		}},
+	}
+}
+
+// verifies that a flattened task group can be expanded into an equal update parameter
+func TestTaskGroup_ExpandFlatten_Roundtrip(t *testing.T) {
+	taskGroup := getTestTaskGroup(2)
+	resourceData := schema.TestResourceDataRaw(t, ResourceTaskGroup().Schema, nil)
+	err := flattenTaskGroup(resourceData, &taskGroup, testTaskGroupProjectID)
+	require.Nil(t, err)
+	require.Equal(t, testTaskGroupID.String(), resourceData.Id())
+	require.Equal(t, "2.2.0", resourceData.Get("version"))
+	require.Equal(t, 2, resourceData.Get(tgMajorVersion))
+
+	parameter, err := expandTaskGroupUpdateParameter(resourceData, &taskGroup)
+	require.Nil(t, err)
+	require.Equal(t, taskGroup.Id, parameter.Id)
+	require.Equal(t, taskGroup.Revision, parameter.Revision)
+	require.Equal(t, taskGroup.Version, parameter.Version)
+	require.Equal(t, taskGroup.Name, parameter.Name)
+	require.Equal(t, taskGroup.Description, parameter.Description)
+	require.Equal(t, taskGroup.Category, parameter.Category)
+	require.Equal(t, taskGroup.InstanceNameFormat, parameter.InstanceNameFormat)
+	require.ElementsMatch(t, *taskGroup.RunsOn, *parameter.RunsOn)
+	require.Equal(t, taskGroup.Inputs, parameter.Inputs)
+	require.Equal(t, taskGroup.Tasks, parameter.Tasks)
+}
+
+// verifies that a new task group is created as first released major version with the defaults of the web UI
+func TestTaskGroup_ExpandCreateParameter_UsesDefaults(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceTaskGroup().Schema, map[string]interface{}{
+		"name": "Deploy Web App",
+		"task": []interface{}{map[string]interface{}{
+			"task_id":      testTaskGroupTaskID.String(),
+			"version":      "2.*",
+			"display_name": "Run script",
+		}},
+	})
+
+	parameter, err := expandTaskGroupCreateParameter(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, 1, *parameter.Version.Major)
+	require.Equal(t, "Task group: Deploy Web App", *parameter.InstanceNameFormat)
+	require.ElementsMatch(t, defaultTaskGroupRunsOn, *parameter.RunsOn)
+	require.Len(t, *parameter.Tasks, 1)
+	require.Equal(t, "task", *(*parameter.Tasks)[0].Task.DefinitionType)
+}
+
+// verifies that the highest major version which has not been deleted is managed
+func TestTaskGroup_SelectLatestTaskGroup(t *testing.T) {
+	deletedTaskGroup := getTestTaskGroup(3)
+	deletedTaskGroup.Deleted = converter.Bool(true)
+	taskGroups := []taskagent.TaskGroup{getTestTaskGroup(1), getTestTaskGroup(2), deletedTaskGroup}
+
+	latestTaskGroup := selectLatestTaskGroup(&taskGroups)
+	require.NotNil(t, latestTaskGroup)
+	require.Equal(t, 2, *latestTaskGroup.Version.Major)
+
+	require.Nil(t, selectLatestTaskGroup(&[]taskagent.TaskGroup{deletedTaskGroup}))
+}
+
+// verifies that a task group without any remaining version is removed from the state
+func TestTaskGroup_Read_RemovesDeletedTaskGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetTaskGroups(clients.Ctx, taskagent.GetTaskGroupsArgs{
+			Project:     converter.String(testTaskGroupProjectID),
+			TaskGroupId: &testTaskGroupID,
+		}).
+		Return(&[]taskagent.TaskGroup{}, nil).
+		Times(1)
+
+	taskGroup := getTestTaskGroup(1)
+	resourceData := schema.TestResourceDataRaw(t, ResourceTaskGroup().Schema, nil)
+	require.Nil(t, flattenTaskGroup(resourceData, &taskGroup, testTaskGroupProjectID))
+
+	err := resourceTaskGroupRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+func TestTaskGroup_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		AddTaskGroup(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("AddTaskGroup() Failed")).
+		Times(1)
+
+	taskGroup := getTestTaskGroup(1)
+	resourceData := schema.TestResourceDataRaw(t, ResourceTaskGroup().Schema, nil)
+	require.Nil(t, flattenTaskGroup(resourceData, &taskGroup, testTaskGroupProjectID))
+
+	err := resourceTaskGroupCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "AddTaskGroup() Failed")
+}
+
+func TestTaskGroup_Update_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskGroup := getTestTaskGroup(1)
+	taskAgentClient.
+		EXPECT().
+		GetTaskGroups(clients.Ctx, gomock.Any()).
+		Return(&[]taskagent.TaskGroup{taskGroup}, nil).
+		Times(1)
+	taskAgentClient.
+		EXPECT().
+		UpdateTaskGroup(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("UpdateTaskGroup() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceTaskGroup().Schema, nil)
+	require.Nil(t, flattenTaskGroup(resourceData, &taskGroup, testTaskGroupProjectID))
+
+	err := resourceTaskGroupUpdate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "UpdateTaskGroup() Failed")
+}
+
+func TestTaskGroup_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		DeleteTaskGroup(clients.Ctx, taskagent.DeleteTaskGroupArgs{
+			Project:     converter.String(testTaskGroupProjectID),
+			TaskGroupId: &testTaskGroupID,
+		}).
+		Return(errors.New("DeleteTaskGroup() Failed")).
+		Times(1)
+
+	taskGroup := getTestTaskGroup(1)
+	resourceData := schema.TestResourceDataRaw(t, ResourceTaskGroup().Schema, nil)
+	require.Nil(t, flattenTaskGroup(resourceData, &taskGroup, testTaskGroupProjectID))
+
+	err := resourceTaskGroupDelete(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "DeleteTaskGroup() Failed")
+}
+
+// verifies that the major version of a task group can only be increased to the next major version
+func TestTaskGroup_ValidateMajorVersionChange(t *testing.T) {
+	require.Nil(t, validateTaskGroupMajorVersionChange(1, 2))
+
+	err := validateTaskGroupMajorVersionChange(2, 1)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "cannot be decreased")
+
+	err = validateTaskGroupMajorVersionChange(1, 3)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "can only be increased by one")
+}
+
+func getTestTaskGroupClients(t *testing.T, handler http.HandlerFunc) (*client.AggregatedClient, *testhelper.RestServer) {
+	server := testhelper.NewRestServer(t, map[uuid.UUID]string{
+		taskGroupsLocationID: "{project}/_apis/distributedtask/taskgroups/{taskGroupId}",
+	}, handler)
+	clients := &client.AggregatedClient{
+		TaskAgentClient: &taskagent.ClientImpl{Client: *server.Client},
+		Ctx:             context.Background(),
+	}
+	return clients, server
+}
+
+// verifies that the configuration is saved as draft of the latest version, which is then published as new major version
+func TestTaskGroup_PublishMajorVersion_PublishesDraft(t *testing.T) {
+	draftTaskGroup := getTestTaskGroup(2)
+	draftID := uuid.New()
+	draftTaskGroup.Id = &draftID
+	draftTaskGroup.Revision = converter.Int(1)
+	publishedTaskGroup := getTestTaskGroup(2)
+	publishedTaskGroup.Preview = converter.Bool(true)
+
+	taskGroupsPath := "/" + testTaskGroupProjectID + "/_apis/distributedtask/taskgroups"
+	clients, server := getTestTaskGroupClients(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == taskGroupsPath:
+			var parameter taskagent.TaskGroupCreateParameter
+			testhelper.ReadRestRequestBody(t, r, &parameter)
+			assert.Equal(t, testTaskGroupID, *parameter.ParentDefinitionId)
+			assert.Equal(t, 2, *parameter.Version.Major)
+			testhelper.WriteRestResponse(t, w, http.StatusOK, draftTaskGroup)
+		case r.Method == http.MethodPut && r.URL.Path == taskGroupsPath+"/"+testTaskGroupID.String():
+			var metadata taskagent.PublishTaskGroupMetadata
+			testhelper.ReadRestRequestBody(t, r, &metadata)
+			assert.Equal(t, draftID, *metadata.TaskGroupId)
+			assert.Equal(t, 1, *metadata.TaskGroupRevision)
+			assert.Equal(t, 3, *metadata.ParentDefinitionRevision)
+			assert.True(t, *metadata.Preview)
+			testhelper.WriteRestResponse(t, w, http.StatusOK, map[string]interface{}{
+				"count": 1,
+				"value": []taskagent.TaskGroup{publishedTaskGroup},
+			})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	defer server.Close()
+
+	latestTaskGroup := getTestTaskGroup(1)
+	resourceData := schema.TestResourceDataRaw(t, ResourceTaskGroup().Schema, nil)
+	require.Nil(t, flattenTaskGroup(resourceData, &latestTaskGroup, testTaskGroupProjectID))
+	resourceData.Set(tgMajorVersion, 2)
+	resourceData.Set(tgPreview, true)
+
+	err := publishTaskGroupMajorVersion(clients, resourceData, &latestTaskGroup)
+	require.Nil(t, err)
+}
+
+// verifies that a failing publication of the draft is reported
+func TestTaskGroup_PublishMajorVersion_DoesNotSwallowError(t *testing.T) {
+	draftTaskGroup := getTestTaskGroup(2)
+	clients, server := getTestTaskGroupClients(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			testhelper.WriteRestResponse(t, w, http.StatusOK, draftTaskGroup)
+			return
+		}
+		testhelper.WriteRestError(t, w, http.StatusInternalServerError, "PublishTaskGroup() Failed")
+	})
+	defer server.Close()
+
+	latestTaskGroup := getTestTaskGroup(1)
+	resourceData := schema.TestResourceDataRaw(t, ResourceTaskGroup().Schema, nil)
+	require.Nil(t, flattenTaskGroup(resourceData, &latestTaskGroup, testTaskGroupProjectID))
+
+	err := publishTaskGroupMajorVersion(clients, resourceData, &latestTaskGroup)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "PublishTaskGroup() Failed")
+}
+
+// verifies that a preview is released without disabling the prior major versions
+func TestTaskGroup_PublishPreview_ReleasesPreview(t *testing.T) {
+	releasedTaskGroup := getTestTaskGroup(2)
+	clients, server := getTestTaskGroupClients(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/"+testTaskGroupProjectID+"/_apis/distributedtask/taskgroups/"+testTaskGroupID.String(), r.URL.Path)
+		assert.Equal(t, "false", r.URL.Query().Get("disablePriorVersions"))
+
+		var taskGroup taskagent.TaskGroup
+		testhelper.ReadRestRequestBody(t, r, &taskGroup)
+		assert.Equal(t, testTaskGroupID, *taskGroup.Id)
+		assert.False(t, *taskGroup.Preview)
+		testhelper.WriteRestResponse(t, w, http.StatusOK, map[string]interface{}{
+			"count": 1,
+			"value": []taskagent.TaskGroup{releasedTaskGroup},
+		})
+	})
+	defer server.Close()
+
+	taskGroups, err := publishPreviewTaskGroup(clients, testTaskGroupProjectID, &releasedTaskGroup, false)
+	require.Nil(t, err)
+	require.Len(t, *taskGroups, 1)
+	require.Equal(t, 2, *(*taskGroups)[0].Version.Major)
+}
+
+func TestTaskGroup_PublishPreview_DoesNotSwallowError(t *testing.T) {
+	clients, server := getTestTaskGroupClients(t, func(w http.ResponseWriter, r *http.Request) {
+		testhelper.WriteRestError(t, w, http.StatusInternalServerError, "PublishPreviewTaskGroup() Failed")
+	})
+	defer server.Close()
+
+	taskGroup := getTestTaskGroup(2)
+	_, err := publishPreviewTaskGroup(clients, testTaskGroupProjectID, &taskGroup, false)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "PublishPreviewTaskGroup() Failed")
+}
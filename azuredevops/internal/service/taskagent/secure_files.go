@@ -0,0 +1,81 @@
+package taskagent
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+)
+
+// The SDK contains the model of a secure file, but not the operations of the
+// distributed task secure files REST endpoints.
+var secureFilesLocationID = uuid.MustParse("adcfd8bc-b184-43ba-bd84-7c8c6a2831e6")
+
+const secureFilesAPIVersion = "5.1-preview.1"
+
+// using: POST https://dev.azure.com/{organization}/{project}/_apis/distributedtask/securefiles?name={name}&api-version=5.1-preview.1
+func uploadSecureFile(clients *client.AggregatedClient, projectID string, name string, content []byte) (*taskagent.SecureFile, error) {
+	var secureFile taskagent.SecureFile
+	err := utils.SendRestRequest(clients.Ctx, clients.TaskAgentClient, &utils.RestRequest{
+		Method:     http.MethodPost,
+		LocationID: secureFilesLocationID,
+		APIVersion: secureFilesAPIVersion,
+		RouteValues: map[string]string{
+			"project": projectID,
+		},
+		QueryParameters: url.Values{
+			"name": []string{name},
+		},
+		Body:      bytes.NewReader(content),
+		MediaType: "application/octet-stream",
+	}, &secureFile)
+	return &secureFile, err
+}
+
+// using: GET https://dev.azure.com/{organization}/{project}/_apis/distributedtask/securefiles/{secureFileId}?api-version=5.1-preview.1
+func getSecureFile(clients *client.AggregatedClient, projectID string, secureFileID string) (*taskagent.SecureFile, error) {
+	var secureFile taskagent.SecureFile
+	err := utils.SendRestRequest(clients.Ctx, clients.TaskAgentClient, &utils.RestRequest{
+		Method:     http.MethodGet,
+		LocationID: secureFilesLocationID,
+		APIVersion: secureFilesAPIVersion,
+		RouteValues: map[string]string{
+			"project":      projectID,
+			"secureFileId": secureFileID,
+		},
+	}, &secureFile)
+	return &secureFile, err
+}
+
+// using: PATCH https://dev.azure.com/{organization}/{project}/_apis/distributedtask/securefiles/{secureFileId}?api-version=5.1-preview.1
+func updateSecureFile(clients *client.AggregatedClient, projectID string, secureFile *taskagent.SecureFile) (*taskagent.SecureFile, error) {
+	var updatedSecureFile taskagent.SecureFile
+	err := utils.SendRestRequest(clients.Ctx, clients.TaskAgentClient, &utils.RestRequest{
+		Method:     http.MethodPatch,
+		LocationID: secureFilesLocationID,
+		APIVersion: secureFilesAPIVersion,
+		RouteValues: map[string]string{
+			"project":      projectID,
+			"secureFileId": secureFile.Id.String(),
+		},
+		Body: secureFile,
+	}, &updatedSecureFile)
+	return &updatedSecureFile, err
+}
+
+// using: DELETE https://dev.azure.com/{organization}/{project}/_apis/distributedtask/securefiles/{secureFileId}?api-version=5.1-preview.1
+func deleteSecureFile(clients *client.AggregatedClient, projectID string, secureFileID string) error {
+	return utils.SendRestRequest(clients.Ctx, clients.TaskAgentClient, &utils.RestRequest{
+		Method:     http.MethodDelete,
+		LocationID: secureFilesLocationID,
+		APIVersion: secureFilesAPIVersion,
+		RouteValues: map[string]string{
+			"project":      projectID,
+			"secureFileId": secureFileID,
+		},
+	}, nil)
+}
@@ -0,0 +1,47 @@
+package taskagent
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+)
+
+// The SDK can create, update and list task groups, but it cannot publish a draft of a task group
+// as a new major version or release a major version which has been published as preview.
+var taskGroupsLocationID = uuid.MustParse("6c08ffbf-dbf1-4f9a-94e5-a1cbd47005e7")
+
+const taskGroupsAPIVersion = "5.1-preview.1"
+
+// using: PUT https://dev.azure.com/{organization}/{project}/_apis/distributedtask/taskgroups/{parentTaskGroupId}?api-version=5.1-preview.1
+func publishTaskGroup(clients *client.AggregatedClient, projectID string, parentTaskGroupID *uuid.UUID, metadata *taskagent.PublishTaskGroupMetadata) (*[]taskagent.TaskGroup, error) {
+	return sendTaskGroupRequest(clients, http.MethodPut, projectID, parentTaskGroupID, nil, metadata)
+}
+
+// using: PATCH https://dev.azure.com/{organization}/{project}/_apis/distributedtask/taskgroups/{taskGroupId}?disablePriorVersions={disablePriorVersions}&api-version=5.1-preview.1
+func publishPreviewTaskGroup(clients *client.AggregatedClient, projectID string, taskGroup *taskagent.TaskGroup, disablePriorVersions bool) (*[]taskagent.TaskGroup, error) {
+	return sendTaskGroupRequest(clients, http.MethodPatch, projectID, taskGroup.Id, url.Values{
+		"disablePriorVersions": []string{strconv.FormatBool(disablePriorVersions)},
+	}, taskGroup)
+}
+
+// sendTaskGroupRequest sends the body as JSON and unmarshals the task groups of the response
+func sendTaskGroupRequest(clients *client.AggregatedClient, method string, projectID string, taskGroupID *uuid.UUID, queryParameters url.Values, body interface{}) (*[]taskagent.TaskGroup, error) {
+	var taskGroups []taskagent.TaskGroup
+	err := utils.SendRestCollectionRequest(clients.Ctx, clients.TaskAgentClient, &utils.RestRequest{
+		Method:     method,
+		LocationID: taskGroupsLocationID,
+		APIVersion: taskGroupsAPIVersion,
+		RouteValues: map[string]string{
+			"project":     projectID,
+			"taskGroupId": taskGroupID.String(),
+		},
+		QueryParameters: queryParameters,
+		Body:            body,
+	}, &taskGroups)
+	return &taskGroups, err
+}
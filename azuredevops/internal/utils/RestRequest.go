@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+)
+
+// RestRequest describes a request to a REST endpoint of Azure DevOps whose operation is not part of the SDK
+type RestRequest struct {
+	Method          string
+	LocationID      uuid.UUID
+	APIVersion      string
+	RouteValues     map[string]string
+	QueryParameters url.Values
+	// Body is sent as JSON, unless it is an io.Reader which is sent as is with the MediaType
+	Body      interface{}
+	MediaType string
+}
+
+// SendRestRequest sends the request through the SDK client of the service area and unmarshals the response into the result, if any
+func SendRestRequest(ctx context.Context, sdkClient interface{}, request *RestRequest, result interface{}) error {
+	client, resp, err := sendRestRequest(ctx, sdkClient, request)
+	if err != nil || result == nil {
+		return err
+	}
+	return client.UnmarshalBody(resp, result)
+}
+
+// SendRestCollectionRequest sends the request through the SDK client of the service area and unmarshals the values of the collection response into the result
+func SendRestCollectionRequest(ctx context.Context, sdkClient interface{}, request *RestRequest, result interface{}) error {
+	client, resp, err := sendRestRequest(ctx, sdkClient, request)
+	if err != nil {
+		return err
+	}
+	return client.UnmarshalCollectionBody(resp, result)
+}
+
+func sendRestRequest(ctx context.Context, sdkClient interface{}, request *RestRequest) (*azuredevops.Client, *http.Response, error) {
+	client, err := getSdkClient(sdkClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mediaType := request.MediaType
+	if mediaType == "" {
+		mediaType = "application/json"
+	}
+
+	var body io.Reader
+	switch requestBody := request.Body.(type) {
+	case nil:
+	case io.Reader:
+		body = requestBody
+	default:
+		content, err := json.Marshal(requestBody)
+		if err != nil {
+			return nil, nil, err
+		}
+		body = bytes.NewReader(content)
+	}
+
+	resp, err := client.Send(ctx, request.Method, request.LocationID, request.APIVersion, request.RouteValues, request.QueryParameters, body, mediaType, "application/json", nil)
+	return client, resp, err
+}
+
+// getSdkClient returns the REST client of the SDK client of a service area
+func getSdkClient(sdkClient interface{}) (*azuredevops.Client, error) {
+	switch clientImpl := sdkClient.(type) {
+	case *build.ClientImpl:
+		return &clientImpl.Client, nil
+	case *git.ClientImpl:
+		return &clientImpl.Client, nil
+	case *taskagent.ClientImpl:
+		return &clientImpl.Client, nil
+	}
+	return nil, fmt.Errorf("Invalid Azure DevOps client implementation %T", sdkClient)
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRestLocationID = uuid.MustParse("a3a6c3c7-3e60-4bbb-9d3c-9ab4a1a0e6f1")
+
+type testRestResource struct {
+	Id   *int    `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+func getTestRestClient(t *testing.T, handler http.HandlerFunc) (*git.ClientImpl, *testhelper.RestServer) {
+	server := testhelper.NewRestServer(t, map[uuid.UUID]string{
+		testRestLocationID: "{project}/_apis/test/resources/{resourceId}",
+	}, handler)
+	return &git.ClientImpl{Client: *server.Client}, server
+}
+
+func TestSendRestRequest_SendsJSONBody(t *testing.T) {
+	name := "web"
+	sdkClient, server := getTestRestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/project/_apis/test/resources/7", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("force"))
+		assert.Contains(t, r.Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, r.Header.Get("Accept"), "api-version=5.1-preview.1")
+
+		var resource testRestResource
+		testhelper.ReadRestRequestBody(t, r, &resource)
+		assert.Equal(t, name, *resource.Name)
+		testhelper.WriteRestResponse(t, w, http.StatusOK, testRestResource{Id: &[]int{7}[0], Name: resource.Name})
+	})
+	defer server.Close()
+
+	var result testRestResource
+	err := SendRestRequest(context.Background(), sdkClient, &RestRequest{
+		Method:          http.MethodPatch,
+		LocationID:      testRestLocationID,
+		APIVersion:      "5.1-preview.1",
+		RouteValues:     map[string]string{"project": "project", "resourceId": "7"},
+		QueryParameters: url.Values{"force": []string{"true"}},
+		Body:            testRestResource{Name: &name},
+	}, &result)
+	require.Nil(t, err)
+	require.Equal(t, 7, *result.Id)
+	require.Equal(t, name, *result.Name)
+}
+
+func TestSendRestRequest_SendsReaderWithMediaType(t *testing.T) {
+	sdkClient, server := getTestRestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("Content-Type"), "application/octet-stream")
+		body, err := ioutil.ReadAll(r.Body)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("content"), body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	err := SendRestRequest(context.Background(), sdkClient, &RestRequest{
+		Method:      http.MethodPost,
+		LocationID:  testRestLocationID,
+		APIVersion:  "5.1-preview.1",
+		RouteValues: map[string]string{"project": "project"},
+		Body:        bytes.NewReader([]byte("content")),
+		MediaType:   "application/octet-stream",
+	}, nil)
+	require.Nil(t, err)
+}
+
+func TestSendRestCollectionRequest_UnmarshalsValues(t *testing.T) {
+	sdkClient, server := getTestRestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testhelper.WriteRestResponse(t, w, http.StatusOK, map[string]interface{}{
+			"count": 2,
+			"value": []testRestResource{{Id: &[]int{1}[0]}, {Id: &[]int{2}[0]}},
+		})
+	})
+	defer server.Close()
+
+	var result []testRestResource
+	err := SendRestCollectionRequest(context.Background(), sdkClient, &RestRequest{
+		Method:      http.MethodGet,
+		LocationID:  testRestLocationID,
+		APIVersion:  "5.1-preview.1",
+		RouteValues: map[string]string{"project": "project"},
+	}, &result)
+	require.Nil(t, err)
+	require.Len(t, result, 2)
+}
+
+func TestSendRestRequest_ReturnsServiceError(t *testing.T) {
+	sdkClient, server := getTestRestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testhelper.WriteRestError(t, w, http.StatusNotFound, "Resource 7 not found")
+	})
+	defer server.Close()
+
+	err := SendRestRequest(context.Background(), sdkClient, &RestRequest{
+		Method:      http.MethodGet,
+		LocationID:  testRestLocationID,
+		APIVersion:  "5.1-preview.1",
+		RouteValues: map[string]string{"project": "project", "resourceId": "7"},
+	}, &testRestResource{})
+	require.NotNil(t, err)
+	require.True(t, ResponseWasNotFound(err))
+	require.Contains(t, err.Error(), "Resource 7 not found")
+}
+
+func TestSendRestRequest_RejectsUnknownClient(t *testing.T) {
+	err := SendRestRequest(context.Background(), &azuredevops.Client{}, &RestRequest{}, nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Invalid Azure DevOps client implementation")
+}
@@ -11,3 +11,8 @@ import (
 func CaseDifference(_, old, new string, _ *schema.ResourceData) bool {
 	return strings.EqualFold(old, new)
 }
+
+// SpaceDifference reports whether old and new are equal after removing leading and trailing white space
+func SpaceDifference(_, old, new string, _ *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
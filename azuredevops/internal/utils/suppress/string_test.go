@@ -51,3 +51,45 @@ func TestCaseDifference(t *testing.T) {
 		})
 	}
 }
+
+func TestSpaceDifference(t *testing.T) {
+	cases := []struct {
+		Name     string
+		StringA  string
+		StringB  string
+		Suppress bool
+	}{
+		{
+			Name:     "empty",
+			StringA:  "",
+			StringB:  "",
+			Suppress: true,
+		},
+		{
+			Name:     "different text",
+			StringA:  "ye old text",
+			StringB:  "ye different text",
+			Suppress: false,
+		},
+		{
+			Name:     "same text trailing newline",
+			StringA:  "ye same text\n",
+			StringB:  "ye same text",
+			Suppress: true,
+		},
+		{
+			Name:     "same text different inner space",
+			StringA:  "ye same  text",
+			StringB:  "ye same text",
+			Suppress: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if SpaceDifference("test", tc.StringA, tc.StringB, nil) != tc.Suppress {
+				t.Fatalf("Expected SpaceDifference to return %t for '%q' == '%q'", tc.Suppress, tc.StringA, tc.StringB)
+			}
+		})
+	}
+}
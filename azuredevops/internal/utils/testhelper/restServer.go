@@ -0,0 +1,101 @@
+package testhelper
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+)
+
+// RestServer is a fake Azure DevOps service for the REST endpoints which are not part of the SDK.
+// It registers the resource locations of the endpoints and passes all other requests to a handler.
+type RestServer struct {
+	server *httptest.Server
+	// Client is an SDK client connected to the fake service
+	Client *azuredevops.Client
+}
+
+// NewRestServer starts a fake Azure DevOps service, which registers a resource location for each of the route templates
+func NewRestServer(t *testing.T, routeTemplates map[uuid.UUID]string, handler http.HandlerFunc) *RestServer {
+	locations := []azuredevops.ApiResourceLocation{}
+	for locationID, routeTemplate := range routeTemplates {
+		id := locationID
+		locations = append(locations, azuredevops.ApiResourceLocation{
+			Id:              &id,
+			Area:            stringPointer("test"),
+			ResourceName:    stringPointer("test"),
+			RouteTemplate:   stringPointer(routeTemplate),
+			MinVersion:      stringPointer("1.0"),
+			MaxVersion:      stringPointer("6.0"),
+			ReleasedVersion: stringPointer("6.0"),
+			ResourceVersion: intPointer(1),
+		})
+	}
+
+	// the SDK caches the resource locations by the base URL, which must therefore be unique for each fake service
+	basePath := "/" + uuid.New().String()
+	mux := http.NewServeMux()
+	mux.HandleFunc(basePath+"/_apis", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodOptions {
+			http.NotFound(w, r)
+			return
+		}
+		WriteRestResponse(t, w, http.StatusOK, map[string]interface{}{
+			"count": len(locations),
+			"value": locations,
+		})
+	})
+	mux.Handle("/", http.StripPrefix(basePath, handler))
+
+	server := httptest.NewServer(mux)
+	return &RestServer{
+		server: server,
+		Client: azuredevops.NewClient(azuredevops.NewPatConnection(server.URL, ""), server.URL+basePath),
+	}
+}
+
+// Close shuts down the fake service
+func (s *RestServer) Close() {
+	s.server.Close()
+}
+
+// WriteRestResponse writes the value as JSON response with the status code
+func WriteRestResponse(t *testing.T, w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		t.Errorf("Unable to write response: %v", err)
+	}
+}
+
+// WriteRestError writes an error response with the status code, which the SDK returns as azuredevops.WrappedError
+func WriteRestError(t *testing.T, w http.ResponseWriter, statusCode int, message string) {
+	WriteRestResponse(t, w, statusCode, map[string]interface{}{
+		"message": message,
+	})
+}
+
+// ReadRestRequestBody unmarshals the JSON body of a request into the value
+func ReadRestRequestBody(t *testing.T, r *http.Request, value interface{}) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Errorf("Unable to read request body: %v", err)
+		return
+	}
+	if err := json.Unmarshal(body, value); err != nil {
+		t.Errorf("Unable to unmarshal request body %q: %v", strings.TrimSpace(string(body)), err)
+	}
+}
+
+func stringPointer(value string) *string {
+	return &value
+}
+
+func intPointer(value int) *int {
+	return &value
+}
@@ -257,3 +257,46 @@ func FindMapInSetWithGivenKeyValue(d *schema.ResourceData, setName string, keyNa
 	}
 	return nil
 }
+
+// FilterMapToStateKeys returns the values of a `TypeMap` attribute of a list element which are part of the state.
+// The service returns values like the inputs of a task also for the keys which are not configured because they use
+// the default value. All values are returned if the list element is not part of the state, e.g. during an import.
+func FilterMapToStateKeys(d *schema.ResourceData, listKey string, index int, mapKey string, values map[string]interface{}) map[string]interface{} {
+	if index >= d.Get(listKey+".#").(int) {
+		return values
+	}
+
+	stateValues, _ := d.Get(fmt.Sprintf("%s.%d.%s", listKey, index, mapKey)).(map[string]interface{})
+	filteredValues := map[string]interface{}{}
+	for key, value := range values {
+		if _, ok := stateValues[key]; ok {
+			filteredValues[key] = value
+		}
+	}
+	return filteredValues
+}
+
+// ComputedSchema returns a copy of a resource schema in which all attributes are computed and not configurable
+func ComputedSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	result := make(map[string]*schema.Schema, len(resourceSchema))
+	for key, value := range resourceSchema {
+		attribute := &schema.Schema{
+			Type:      value.Type,
+			Computed:  true,
+			Sensitive: value.Sensitive,
+			Set:       value.Set,
+		}
+		switch elem := value.Elem.(type) {
+		case *schema.Resource:
+			attribute.Elem = &schema.Resource{
+				Schema: ComputedSchema(elem.Schema),
+			}
+		case *schema.Schema:
+			attribute.Elem = &schema.Schema{
+				Type: elem.Type,
+			}
+		}
+		result[key] = attribute
+	}
+	return result
+}
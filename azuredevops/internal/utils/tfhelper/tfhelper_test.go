@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
@@ -91,3 +92,41 @@ func TestGetRealProjectId(t *testing.T) {
 		require.Equal(t, tc.exceptProjectID, projectID)
 	}
 }
+
+func TestFilterMapToStateKeys(t *testing.T) {
+	testSchema := map[string]*schema.Schema{
+		"task": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"inputs": {
+						Type:     schema.TypeMap,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+	}
+	values := map[string]interface{}{
+		"script":           "echo hello",
+		"workingDirectory": "",
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, testSchema, map[string]interface{}{
+		"task": []interface{}{
+			map[string]interface{}{
+				"inputs": map[string]interface{}{"script": "echo hello"},
+			},
+			map[string]interface{}{},
+		},
+	})
+	require.Equal(t, map[string]interface{}{"script": "echo hello"}, FilterMapToStateKeys(resourceData, "task", 0, "inputs", values))
+	require.Equal(t, map[string]interface{}{}, FilterMapToStateKeys(resourceData, "task", 1, "inputs", values))
+
+	// the values are not filtered if the list element is not part of the state, e.g. during an import
+	require.Equal(t, values, FilterMapToStateKeys(resourceData, "task", 2, "inputs", values))
+	importedResourceData := schema.TestResourceDataRaw(t, testSchema, nil)
+	require.Equal(t, values, FilterMapToStateKeys(importedResourceData, "task", 0, "inputs", values))
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/graph"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/memberentitlementmanagement"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/permissions"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/pipelineschecks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/release"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/serviceendpoint"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/taskagent"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/workitemtracking"
@@ -29,8 +31,15 @@ func Provider() *schema.Provider {
 			"azuredevops_branch_policy_merge_types":         policy.ResourceBranchPolicyMergeTypes(),
 			"azuredevops_branch_policy_status_check":        policy.ResourceBranchPolicyStatusCheck(),
 			"azuredevops_build_definition":                  build.ResourceBuildDefinition(),
+			"azuredevops_build_folder":                      build.ResourceBuildFolder(),
+			"azuredevops_build_run":                         build.ResourceBuildRun(),
+			"azuredevops_check_approval":                    pipelineschecks.ResourceCheckApproval(),
+			"azuredevops_check_branch_control":              pipelineschecks.ResourceCheckBranchControl(),
+			"azuredevops_check_business_hours":              pipelineschecks.ResourceCheckBusinessHours(),
+			"azuredevops_check_required_template":           pipelineschecks.ResourceCheckRequiredTemplate(),
 			"azuredevops_project":                           core.ResourceProject(),
 			"azuredevops_project_features":                  core.ResourceProjectFeatures(),
+			"azuredevops_release_definition":                release.ResourceReleaseDefinition(),
 			"azuredevops_variable_group":                    taskagent.ResourceVariableGroup(),
 			"azuredevops_serviceendpoint_artifactory":       serviceendpoint.ResourceServiceEndpointArtifactory(),
 			"azuredevops_serviceendpoint_aws":               serviceendpoint.ResourceServiceEndpointAws(),
@@ -48,10 +57,19 @@ func Provider() *schema.Provider {
 			"azuredevops_serviceendpoint_ssh":               serviceendpoint.ResourceServiceEndpointSSH(),
 			"azuredevops_serviceendpoint_npm":               serviceendpoint.ResourceServiceEndpointNpm(),
 			"azuredevops_git_repository":                    git.ResourceGitRepository(),
+			"azuredevops_git_branch_lock":                   git.ResourceGitBranchLock(),
+			"azuredevops_git_repository_branch":             git.ResourceGitRepositoryBranch(),
+			"azuredevops_git_repository_file":               git.ResourceGitRepositoryFile(),
+			"azuredevops_git_repository_tag":                git.ResourceGitRepositoryTag(),
 			"azuredevops_user_entitlement":                  memberentitlementmanagement.ResourceUserEntitlement(),
 			"azuredevops_group_membership":                  graph.ResourceGroupMembership(),
 			"azuredevops_agent_pool":                        taskagent.ResourceAgentPool(),
 			"azuredevops_agent_queue":                       taskagent.ResourceAgentQueue(),
+			"azuredevops_deployment_group":                  taskagent.ResourceDeploymentGroup(),
+			"azuredevops_environment":                       taskagent.ResourceEnvironment(),
+			"azuredevops_environment_resource_kubernetes":   taskagent.ResourceEnvironmentResourceKubernetes(),
+			"azuredevops_secure_file":                       taskagent.ResourceSecureFile(),
+			"azuredevops_task_group":                        taskagent.ResourceTaskGroup(),
 			"azuredevops_group":                             graph.ResourceGroup(),
 			"azuredevops_project_permissions":               permissions.ResourceProjectPermissions(),
 			"azuredevops_git_permissions":                   permissions.ResourceGitPermissions(),
@@ -61,18 +79,26 @@ func Provider() *schema.Provider {
 			"azuredevops_build_definition_permissions":      permissions.ResourceBuildDefinitionPermissions(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"azuredevops_agent_pool":       taskagent.DataAgentPool(),
-			"azuredevops_agent_pools":      taskagent.DataAgentPools(),
-			"azuredevops_agent_queue":      taskagent.DataAgentQueue(),
-			"azuredevops_client_config":    service.DataClientConfig(),
-			"azuredevops_group":            graph.DataGroup(),
-			"azuredevops_project":          core.DataProject(),
-			"azuredevops_projects":         core.DataProjects(),
-			"azuredevops_git_repositories": git.DataGitRepositories(),
-			"azuredevops_git_repository":   git.DataGitRepository(),
-			"azuredevops_users":            graph.DataUsers(),
-			"azuredevops_area":             workitemtracking.DataArea(),
-			"azuredevops_iteration":        workitemtracking.DataIteration(),
+			"azuredevops_agent_pool":              taskagent.DataAgentPool(),
+			"azuredevops_agent_pools":             taskagent.DataAgentPools(),
+			"azuredevops_agent_queue":             taskagent.DataAgentQueue(),
+			"azuredevops_client_config":           service.DataClientConfig(),
+			"azuredevops_group":                   graph.DataGroup(),
+			"azuredevops_project":                 core.DataProject(),
+			"azuredevops_projects":                core.DataProjects(),
+			"azuredevops_git_repositories":        git.DataGitRepositories(),
+			"azuredevops_git_repository":          git.DataGitRepository(),
+			"azuredevops_git_repository_branches": git.DataGitRepositoryBranches(),
+			"azuredevops_git_repository_file":     git.DataGitRepositoryFile(),
+			"azuredevops_users":                   graph.DataUsers(),
+			"azuredevops_area":                    workitemtracking.DataArea(),
+			"azuredevops_build_definition":        build.DataBuildDefinition(),
+			"azuredevops_build_definitions":       build.DataBuildDefinitions(),
+			"azuredevops_release_definition":      release.DataReleaseDefinition(),
+			"azuredevops_release_definitions":     release.DataReleaseDefinitions(),
+			"azuredevops_task_group":              taskagent.DataTaskGroup(),
+			"azuredevops_deployment_targets":      taskagent.DataDeploymentTargets(),
+			"azuredevops_iteration":               workitemtracking.DataIteration(),
 		},
 		Schema: map[string]*schema.Schema{
 			"org_service_url": {
@@ -12,6 +12,12 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_resource_authorization",
 		"azuredevops_build_definition",
 		"azuredevops_build_definition_permissions",
+		"azuredevops_build_folder",
+		"azuredevops_build_run",
+		"azuredevops_check_approval",
+		"azuredevops_check_branch_control",
+		"azuredevops_check_business_hours",
+		"azuredevops_check_required_template",
 		"azuredevops_branch_policy_build_validation",
 		"azuredevops_branch_policy_min_reviewers",
 		"azuredevops_branch_policy_auto_reviewers",
@@ -21,6 +27,7 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_branch_policy_status_check",
 		"azuredevops_project",
 		"azuredevops_project_features",
+		"azuredevops_release_definition",
 		"azuredevops_serviceendpoint_github",
 		"azuredevops_serviceendpoint_github_enterprise",
 		"azuredevops_serviceendpoint_dockerregistry",
@@ -38,11 +45,20 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_serviceendpoint_npm",
 		"azuredevops_variable_group",
 		"azuredevops_git_repository",
+		"azuredevops_git_branch_lock",
+		"azuredevops_git_repository_branch",
+		"azuredevops_git_repository_file",
+		"azuredevops_git_repository_tag",
 		"azuredevops_user_entitlement",
 		"azuredevops_group_membership",
 		"azuredevops_group",
 		"azuredevops_agent_pool",
 		"azuredevops_agent_queue",
+		"azuredevops_deployment_group",
+		"azuredevops_environment",
+		"azuredevops_environment_resource_kubernetes",
+		"azuredevops_secure_file",
+		"azuredevops_task_group",
 		"azuredevops_project_permissions",
 		"azuredevops_git_permissions",
 		"azuredevops_workitemquery_permissions",
@@ -67,12 +83,20 @@ func TestProvider_HasChildDataSources(t *testing.T) {
 		"azuredevops_projects",
 		"azuredevops_git_repositories",
 		"azuredevops_git_repository",
+		"azuredevops_git_repository_branches",
+		"azuredevops_git_repository_file",
 		"azuredevops_users",
 		"azuredevops_agent_pool",
 		"azuredevops_agent_pools",
 		"azuredevops_agent_queue",
 		"azuredevops_area",
 		"azuredevops_iteration",
+		"azuredevops_build_definition",
+		"azuredevops_build_definitions",
+		"azuredevops_release_definition",
+		"azuredevops_release_definitions",
+		"azuredevops_task_group",
+		"azuredevops_deployment_targets",
 	}
 
 	dataSources := Provider().DataSourcesMap